@@ -0,0 +1,155 @@
+package wallconnector
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Growth factor between the buckets of native histograms, about 1% so the
+// distribution of the charging current resolves to a fraction of an amp.
+const nativeBucketFactor = 1.01
+
+// aggregates exports the distributions of metrics, built from samples observed
+// outside of scrapes.
+//
+// Metrics with the same name share their histogram and min/max descriptions.
+type aggregates struct {
+	labels prometheus.Labels
+	// Length of the windows of min/max gauges.
+	window time.Duration
+
+	histograms []*prometheus.HistogramVec
+	byName     map[string]*prometheus.HistogramVec
+
+	descs []*prometheus.Desc
+	mins  map[string]*prometheus.Desc
+	maxs  map[string]*prometheus.Desc
+}
+
+func newAggregates(labels prometheus.Labels, window time.Duration) *aggregates {
+	return &aggregates{
+		labels: labels,
+		window: window,
+		byName: make(map[string]*prometheus.HistogramVec),
+		mins:   make(map[string]*prometheus.Desc),
+		maxs:   make(map[string]*prometheus.Desc),
+	}
+}
+
+func (a *aggregates) histogram(v *Metric, ns string) *prometheus.HistogramVec {
+	name := prometheus.BuildFQName("wallconnector", ns, v.GetName())
+	if h, ok := a.byName[name]; ok {
+		return h
+	}
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:        name + "_distribution",
+		Help:        v.GetHelp() + " Distribution of the sampled values.",
+		ConstLabels: a.labels,
+
+		NativeHistogramBucketFactor: nativeBucketFactor,
+	}, v.LabelKeys())
+	a.byName[name] = h
+	a.histograms = append(a.histograms, h)
+	return h
+}
+
+func (a *aggregates) minMax(v *Metric, ns string) *minMax {
+	name := prometheus.BuildFQName("wallconnector", ns, v.GetName())
+	if _, ok := a.mins[name]; !ok {
+		a.mins[name] = prometheus.NewDesc(name+"_min", v.GetHelp()+" Minimum of the values sampled in the last completed window.", v.LabelKeys(), a.labels)
+		a.maxs[name] = prometheus.NewDesc(name+"_max", v.GetHelp()+" Maximum of the values sampled in the last completed window.", v.LabelKeys(), a.labels)
+		a.descs = append(a.descs, a.mins[name], a.maxs[name])
+	}
+	return &minMax{min: a.mins[name], max: a.maxs[name], labels: v.LabelValues(), window: a.window}
+}
+
+func (a *aggregates) Describe(ch chan<- *prometheus.Desc) {
+	for _, h := range a.histograms {
+		h.Describe(ch)
+	}
+	for _, desc := range a.descs {
+		ch <- desc
+	}
+}
+
+// Collect sends the histograms. The min/max gauges are per series, so are
+// collected by the metric set.
+func (a *aggregates) Collect(ch chan<- prometheus.Metric) {
+	for _, h := range a.histograms {
+		h.Collect(ch)
+	}
+}
+
+// minMax tracks the extremes of a series over fixed windows. The last
+// completed window is exported rather than the samples since the last read,
+// so every consumer of the registry, e.g. a scrape and a remote write push,
+// sees the same extremes.
+type minMax struct {
+	min, max *prometheus.Desc
+	labels   []string
+	window   time.Duration
+
+	mu sync.Mutex
+	// Start of the current window, and the extremes of it and the window
+	// before it.
+	start time.Time
+	cur   extremes
+	last  extremes
+}
+
+type extremes struct {
+	lo, hi   float64
+	observed bool
+}
+
+func (e *extremes) add(v float64) {
+	if !e.observed {
+		e.lo, e.hi, e.observed = v, v, true
+		return
+	}
+	e.lo = math.Min(e.lo, v)
+	e.hi = math.Max(e.hi, v)
+}
+
+// roll completes the current window if t is past its end.
+func (m *minMax) roll(t time.Time) {
+	start := t.Truncate(m.window)
+	if !start.After(m.start) {
+		return
+	}
+	if start.Equal(m.start.Add(m.window)) {
+		m.last = m.cur
+	} else {
+		// A whole window passed without samples.
+		m.last = extremes{}
+	}
+	m.start, m.cur = start, extremes{}
+}
+
+func (m *minMax) observe(t time.Time, v float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roll(t)
+	if t.Before(m.start) {
+		// Late for its window, which has already been exported.
+		return
+	}
+	m.cur.add(v)
+}
+
+// collect sends the extremes of the last completed window as of now, if any
+// values were observed in it.
+func (m *minMax) collect(ch chan<- prometheus.Metric, now time.Time) {
+	m.mu.Lock()
+	m.roll(now)
+	last := m.last
+	m.mu.Unlock()
+	if !last.observed {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(m.min, prometheus.GaugeValue, last.lo, m.labels...)
+	ch <- prometheus.MustNewConstMetric(m.max, prometheus.GaugeValue, last.hi, m.labels...)
+}
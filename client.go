@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 )
 
-//go:generate protoc --go_out=. --go_opt=paths=source_relative metrics.proto
+//go:generate go build -o .bin/protoc-gen-wallconnector-prom ./cmd/protoc-gen-wallconnector-prom
+//go:generate protoc --plugin=.bin/protoc-gen-wallconnector-prom --go_out=. --go_opt=paths=source_relative --wallconnector-prom_out=. --wallconnector-prom_opt=paths=source_relative metrics.proto
 
 const (
 	vitalsPath   = "/api/1/vitals"
@@ -19,27 +21,74 @@ const (
 type Client struct {
 	addr   string
 	client *http.Client
+
+	// Resolves the address by serial number, if configured.
+	resolver *resolver
 }
 
 func NewClient(addr string, opts ...ConnectorConfig) (*Client, error) {
 	c := &connectorOpts{
-		Transport: http.DefaultTransport,
+		Transport:      http.DefaultTransport,
+		VerifyInterval: time.Minute,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	return &Client{
+	client := &Client{
 		addr: addr,
 		client: &http.Client{
 			Transport: c.Transport,
 			Timeout:   c.Timeout,
 		},
-	}, nil
+	}
+	if c.SerialNumber != "" {
+		// Probe with the transport and timeout of the client, unless the
+		// discover options say otherwise.
+		discover := []DiscoverConfig{WithDiscoverTransport(c.Transport)}
+		if c.Timeout > 0 {
+			discover = append(discover, WithProbeTimeout(c.Timeout))
+		}
+		client.resolver = &resolver{
+			serial:   c.SerialNumber,
+			subnet:   c.Subnet,
+			opts:     append(discover, c.DiscoverOpts...),
+			interval: c.VerifyInterval,
+			addr:     addr,
+		}
+	}
+	return client, nil
+}
+
+// Addr returns the address of the wallconnector. When resolving by serial
+// number, it is the last address the wallconnector was found at, or empty if
+// it hasn't been found yet.
+func (c *Client) Addr() string {
+	if c.resolver != nil {
+		return c.resolver.current()
+	}
+	return c.addr
 }
 
 func callApi[T any](ctx context.Context, c *Client, path string) (*T, error) {
-	req, err := http.NewRequest(http.MethodGet, "http://"+c.addr+path, nil)
+	if c.resolver == nil {
+		return get[T](ctx, c, c.addr, path)
+	}
+	addr, err := c.resolver.resolve(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	v, err := get[T](ctx, c, addr, path)
+	if err != nil {
+		// Check the wallconnector hasn't moved before the next request.
+		c.resolver.invalidate()
+	}
+	return v, err
+}
+
+// get requests path from the wallconnector at addr.
+func get[T any](ctx context.Context, c *Client, addr, path string) (*T, error) {
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2,12 +2,23 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/events"
+	"github.com/R167/wallconnector/grid"
+	"github.com/R167/wallconnector/otlp"
+	"github.com/R167/wallconnector/remotewrite"
+	"github.com/R167/wallconnector/site"
+	"github.com/R167/wallconnector/solar"
+	"github.com/R167/wallconnector/thermal"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -17,23 +28,89 @@ var (
 	addr   = flag.String("addr", "localhost:8080", "address to listen on")
 	path   = flag.String("path", "/metrics", "path to serve metrics on")
 	target = flag.String("target", "localhost:8081", "target to forward requests to")
+	serial = flag.String("serial", "", "expected serial number of the wall connector, checked on every scrape and added as a label")
+	subnet = flag.String("subnet", "", "subnet to scan for the wall connector with -serial when its address changes, e.g. 192.168.1.0/24")
+
+	pollInterval    = flag.Duration("poll-interval", 5*time.Second, "interval to poll vitals at for events")
+	aggregate       = flag.Bool("aggregate", false, "poll the vitals every -poll-interval and export their distributions between scrapes")
+	aggregateWindow = flag.Duration("aggregate-window", time.Minute, "length of the windows of min/max aggregates with -aggregate")
+	webhooks        = flag.String("webhooks", "", "JSON file of webhooks to notify on charger events")
+	eventsPath      = flag.String("events-path", "", "path to stream charger events on as Server-Sent Events, e.g. /events")
+	gridPath        = flag.String("grid-path", "", "path to serve grid quality events on, e.g. /grid")
+	thermalPath     = flag.String("thermal-path", "", "path to serve the thermal analysis of charging sessions on, e.g. /thermal")
+	thermalLimits   = flag.String("thermal-limits", "", "comma separated sensor=celsius overriding the estimated temperature limits, e.g. handle=65")
+
+	solarURL         = flag.String("solar-url", "", "URL of a JSON feed of the solar production and consumption of the site, to compute the surplus to charge with")
+	solarFile        = flag.String("solar-file", "", "JSON file of the solar production and consumption of the site, instead of -solar-url")
+	solarProduction  = flag.String("solar-production-field", "production_w", "dotted path to the production in watts in the solar feed")
+	solarConsumption = flag.String("solar-consumption-field", "consumption_w", "dotted path to the consumption in watts in the solar feed, including the charger")
+	solarInterval    = flag.Duration("solar-interval", 30*time.Second, "interval to read the solar feed at")
+	solarPath        = flag.String("solar-path", "/solar", "path to serve the latest solar surplus on")
+
+	siteChargers = flag.String("site-chargers", "", "comma separated name=address of the wall connectors sharing a service, to export their combined load")
+	siteLimit    = flag.Float64("site-limit", 0, "rating in amps per phase of the breaker or service feeding -site-chargers")
+	siteDerate   = flag.Float64("site-derate", 0.8, "fraction of -site-limit the chargers may draw")
+
+	counterState = flag.String("counter-state", "", "file to persist lifetime counter offsets to across restarts")
+
+	otlpEndpoint = flag.String("otlp-endpoint", "", "OTLP/HTTP metrics endpoint to push to, e.g. http://localhost:4318/v1/metrics")
+	otlpInterval = flag.Duration("otlp-interval", 30*time.Second, "interval to push OTLP metrics at")
+	otlpHeaders  = flag.String("otlp-headers", "", "comma separated key=value headers to send with OTLP requests")
+
+	remoteWriteURL      = flag.String("remote-write-url", "", "Prometheus remote write endpoint to push to, for when the exporter can't be scraped")
+	remoteWriteInterval = flag.Duration("remote-write-interval", 30*time.Second, "interval to push via remote write at")
+	remoteWriteUser     = flag.String("remote-write-username", "", "username for remote write basic auth")
+	remoteWritePassword = flag.String("remote-write-password", os.Getenv("REMOTE_WRITE_PASSWORD"), "password for remote write basic auth, defaults to $REMOTE_WRITE_PASSWORD")
+	remoteWriteToken    = flag.String("remote-write-bearer-token", os.Getenv("REMOTE_WRITE_BEARER_TOKEN"), "bearer token for remote write, defaults to $REMOTE_WRITE_BEARER_TOKEN")
 )
 
+// Maximum time to send an event to all webhooks.
+const webhookTimeout = 30 * time.Second
+
 func main() {
 	start := time.Now()
 
 	// Listen on the specified address and serve prometheus metrics
 	// from the wall connector target.
 	flag.Parse()
+	for name, interval := range map[string]time.Duration{
+		"-poll-interval":         *pollInterval,
+		"-solar-interval":        *solarInterval,
+		"-otlp-interval":         *otlpInterval,
+		"-remote-write-interval": *remoteWriteInterval,
+	} {
+		if interval <= 0 {
+			log.Fatalf("%s must be positive", name)
+		}
+	}
 
 	// Create a new client for the wall connector.
-	client, err := wallconnector.NewClient(*target)
+	var opts []wallconnector.ConnectorConfig
+	if *subnet != "" {
+		if *serial == "" {
+			log.Fatal("-serial is required with -subnet")
+		}
+		opts = append(opts, wallconnector.WithSerialNumber(*serial, *subnet))
+	}
+	client, err := wallconnector.NewClient(*target, opts...)
 	if err != nil {
 		panic(err)
 	}
 
 	// Create a new collector for the wall connector.
-	collector := wallconnector.NewCollector(client)
+	collectorOpts := []wallconnector.CollectorConfig{wallconnector.WithCounterState(*counterState)}
+	if *serial != "" {
+		collectorOpts = append(collectorOpts, wallconnector.WithExpectedSerial(*serial))
+	}
+	var samples chan wallconnector.VitalsSample
+	if *aggregate {
+		samples = make(chan wallconnector.VitalsSample, 16)
+		collectorOpts = append(collectorOpts,
+			wallconnector.WithVitalsSamples(context.Background(), samples),
+			wallconnector.WithAggregateWindow(*aggregateWindow),
+		)
+	}
+	collector := wallconnector.NewCollector(client, collectorOpts...)
 
 	reg := prometheus.NewPedanticRegistry()
 	reg.MustRegister(
@@ -44,9 +121,120 @@ func main() {
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 	)
 
+	if *siteChargers != "" {
+		if *siteLimit <= 0 {
+			log.Fatal("-site-limit is required with -site-chargers")
+		}
+		chargers, err := parseChargers(*siteChargers)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reg.MustRegister(site.NewBudget(chargers, *siteLimit, site.WithDerate(*siteDerate)))
+	}
+
+	// Poll the wall connector for events.
+	poller := newPoller(client, *pollInterval)
+	if samples != nil {
+		poller.onVitals(func(s wallconnector.VitalsSample) {
+			select {
+			case samples <- s:
+			default:
+				// Never hold up polling on aggregation.
+			}
+		})
+	}
+	if *webhooks != "" {
+		hooks, err := events.LoadWebhooks(*webhooks)
+		if err != nil {
+			panic(err)
+		}
+		notifier := &events.Notifier{Hooks: hooks}
+		poller.onEvent(func(e events.Event) {
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+				defer cancel()
+				notifier.Notify(ctx, e)
+			}()
+		})
+	}
+	var stream *events.Stream
+	if *eventsPath != "" {
+		stream = events.NewStream()
+		poller.onEvent(stream.PublishEvent)
+		poller.onVitals(stream.PublishVitals)
+		http.Handle(*eventsPath, stream)
+	}
+	if *thermalPath != "" {
+		var thermalOpts []thermal.Config
+		if *thermalLimits != "" {
+			sensors, err := thermal.ParseLimits(*thermalLimits)
+			if err != nil {
+				log.Fatal(err)
+			}
+			thermalOpts = append(thermalOpts, thermal.WithSensors(sensors))
+		}
+		analyzer := thermal.NewAnalyzer(thermalOpts...)
+		reg.MustRegister(analyzer)
+		poller.onVitals(analyzer.Vitals)
+		poller.onLifetime(analyzer.Lifetime)
+		http.Handle(*thermalPath, analyzer)
+	}
+	if *gridPath != "" {
+		analyzer := grid.NewAnalyzer()
+		reg.MustRegister(analyzer)
+		poller.onVitals(analyzer.Vitals)
+		http.Handle(*gridPath, analyzer)
+	}
+	solarEnabled := *solarURL != "" || *solarFile != ""
+	if solarEnabled {
+		if *solarURL != "" && *solarFile != "" {
+			log.Fatal("-solar-url and -solar-file are mutually exclusive")
+		}
+		sourceOpts := []solar.SourceConfig{solar.WithFields(*solarProduction, *solarConsumption)}
+		var source solar.Source
+		if *solarURL != "" {
+			source = solar.NewHTTPSource(*solarURL, sourceOpts...)
+		} else {
+			source = solar.NewFileSource(*solarFile, sourceOpts...)
+		}
+		calculator := solar.NewCalculator(source)
+		reg.MustRegister(calculator)
+		poller.onVitals(calculator.Vitals)
+		if stream != nil {
+			calculator.OnSurplus(func(s solar.Surplus) {
+				stream.Publish("solar", s)
+			})
+		}
+		if *solarPath != "" {
+			http.Handle(*solarPath, calculator)
+		}
+		go calculator.Run(context.Background(), *solarInterval)
+	}
+	if *webhooks != "" || *eventsPath != "" || *thermalPath != "" || *gridPath != "" || *aggregate || solarEnabled {
+		go poller.run(context.Background())
+	}
+
+	if *otlpEndpoint != "" {
+		exporter := otlp.NewExporter(*otlpEndpoint, otlp.WithHeaders(parseHeaders(*otlpHeaders)))
+		go exporter.Run(context.Background(), client, *otlpInterval)
+		log.Printf("pushing OTLP metrics to %s every %s", *otlpEndpoint, *otlpInterval)
+	}
+
+	if *remoteWriteURL != "" {
+		pusher := remotewrite.NewPusher(*remoteWriteURL, reg,
+			remotewrite.WithLabels(map[string]string{"job": "wallconnector", "instance": *target}),
+			remotewrite.WithBasicAuth(*remoteWriteUser, *remoteWritePassword),
+			remotewrite.WithBearerToken(*remoteWriteToken),
+		)
+		go pusher.Run(context.Background(), *remoteWriteInterval)
+		log.Printf("pushing via remote write to %s every %s", *remoteWriteURL, *remoteWriteInterval)
+	}
+
 	// Serve the metrics on the specified path.
 	http.Handle(*path, promhttp.HandlerFor(reg, promhttp.HandlerOpts{
-		ErrorLog:         log.Default(),
+		ErrorLog: log.Default(),
+		// Serve wallconnector_serial_mismatch along with the error.
+		ErrorHandling:    promhttp.ContinueOnError,
 		Registry:         reg,
 		ProcessStartTime: start,
 	}))
@@ -55,3 +243,31 @@ func main() {
 		panic(err)
 	}
 }
+
+// parseChargers parses comma separated name=address pairs.
+func parseChargers(s string) ([]site.Charger, error) {
+	var chargers []site.Charger
+	for _, kv := range strings.Split(s, ",") {
+		name, addr, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid charger %q, expected name=address", kv)
+		}
+		client, err := wallconnector.NewClient(strings.TrimSpace(addr))
+		if err != nil {
+			return nil, err
+		}
+		chargers = append(chargers, site.Charger{Name: strings.TrimSpace(name), Vitals: client})
+	}
+	return chargers, nil
+}
+
+// parseHeaders parses comma separated key=value pairs.
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return headers
+}
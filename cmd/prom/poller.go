@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/events"
+)
+
+// How often to poll the lifetime stats for thermal foldbacks.
+const lifetimeInterval = time.Minute
+
+// poller polls the wall connector and dispatches the events detected between
+// polls to its handlers.
+type poller struct {
+	client   *wallconnector.Client
+	interval time.Duration
+	detector *events.Detector
+	handlers []func(events.Event)
+	samplers []func(wallconnector.VitalsSample)
+	lifetime []func(time.Time, *wallconnector.Lifetime)
+}
+
+func newPoller(client *wallconnector.Client, interval time.Duration) *poller {
+	return &poller{
+		client:   client,
+		interval: interval,
+		detector: events.NewDetector(),
+	}
+}
+
+// onEvent registers a handler to call for each event.
+func (p *poller) onEvent(h func(events.Event)) {
+	p.handlers = append(p.handlers, h)
+}
+
+// onVitals registers a handler to call with every changed vitals sample.
+func (p *poller) onVitals(h func(wallconnector.VitalsSample)) {
+	p.samplers = append(p.samplers, h)
+}
+
+// onLifetime registers a handler to call with every lifetime poll.
+func (p *poller) onLifetime(h func(time.Time, *wallconnector.Lifetime)) {
+	p.lifetime = append(p.lifetime, h)
+}
+
+func (p *poller) run(ctx context.Context) {
+	var lastLifetime time.Time
+	for sample := range p.client.Watch(ctx, p.interval) {
+		if sample.Err != nil {
+			log.Printf("polling vitals: %v", sample.Err)
+		} else {
+			for _, h := range p.samplers {
+				h(sample)
+			}
+			p.dispatch(p.detector.Vitals(sample.Time, sample.Vitals))
+		}
+
+		now := sample.Time
+		if now.Sub(lastLifetime) >= lifetimeInterval {
+			lifetime, err := p.client.Lifetime(ctx)
+			if err != nil {
+				log.Printf("polling lifetime: %v", err)
+			} else {
+				lastLifetime = now
+				for _, h := range p.lifetime {
+					h(now, lifetime)
+				}
+				p.dispatch(p.detector.Lifetime(now, lifetime))
+			}
+		}
+	}
+}
+
+func (p *poller) dispatch(evs []events.Event) {
+	for _, e := range evs {
+		log.Printf("event: %s", e.Type)
+		for _, h := range p.handlers {
+			h(e)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+// A protoc plugin which generates reflection-free tables of the prometheus
+// metrics annotated in metrics.proto.
+//
+// For every message with (prometheus) or (derived) annotations, it generates
+// a promFields method returning the annotated metrics along with typed
+// accessors for their raw values, which newMetricSet uses in place of walking
+// the descriptors at runtime. All annotations are validated while generating,
+// so invalid names, labels or types fail the build instead of panicking at
+// scrape time.
+//
+// The annotations are decoded with the Metric type compiled into this plugin,
+// so changes to the Metric message itself require running go generate twice.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/R167/wallconnector/internal/annotation"
+	"github.com/R167/wallconnector/internal/expr"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const protoPackage = protogen.GoImportPath("google.golang.org/protobuf/proto")
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+			if err := generateFile(gen, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func generateFile(gen *protogen.Plugin, f *protogen.File) error {
+	var issues []error
+	var messages []*protogen.Message
+	for _, msg := range f.Messages {
+		if !annotation.Annotated(msg.Desc) {
+			continue
+		}
+		for _, issue := range annotation.Check(msg.Desc) {
+			issues = append(issues, issue)
+		}
+		messages = append(messages, msg)
+	}
+	if err := errors.Join(issues...); err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	metric := findMessage(gen, "Metric")
+	if metric == nil {
+		return fmt.Errorf("%s: Metric message not found", f.Desc.Path())
+	}
+
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+".prom.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-wallconnector-prom. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+	for _, msg := range messages {
+		g.P()
+		generateMessage(g, metric, msg)
+	}
+	return nil
+}
+
+// findMessage returns the Metric message, which may be defined in any file of
+// the request.
+func findMessage(gen *protogen.Plugin, name protoreflect.Name) *protogen.Message {
+	for _, f := range gen.Files {
+		for _, msg := range f.Messages {
+			if msg.Desc.Name() == name {
+				return msg
+			}
+		}
+	}
+	return nil
+}
+
+func generateMessage(g *protogen.GeneratedFile, metric *protogen.Message, msg *protogen.Message) {
+	typ := "*" + msg.GoIdent.GoName
+	table := "promFields" + msg.GoIdent.GoName
+
+	g.P("func (*", msg.GoIdent, ") promFields() []promField[", typ, "] {")
+	g.P("return ", table)
+	g.P("}")
+	g.P()
+	g.P("var ", table, " = []promField[", typ, "]{")
+	for _, field := range msg.Fields {
+		m := annotation.FieldMetric(field.Desc)
+		if m.GetName() == "" || m.GetSkip() {
+			continue
+		}
+		g.P("{")
+		g.P("name: ", strconv.Quote(string(field.Desc.Name())), ",")
+		g.P("metric: ", literal(g, metric, m.ProtoReflect()), ",")
+		if field.Desc.Kind() == protoreflect.Int32Kind {
+			g.P("int32: true,")
+		}
+		g.P("value: func(x ", typ, ") float64 { return ", getter(msg, field.Desc.Name()), " },")
+		g.P("},")
+	}
+	for _, m := range annotation.DerivedMetrics(msg.Desc) {
+		// Expressions have already been validated by annotation.Check.
+		e, _ := expr.Parse(m.GetExpr())
+		g.P("{")
+		g.P("name: ", strconv.Quote(m.GetExpr()), ",")
+		g.P("metric: ", literal(g, metric, m.ProtoReflect()), ",")
+		g.P("value: func(x ", typ, ") float64 { return ", goExpr(msg, e, false), " },")
+		g.P("},")
+	}
+	g.P("}")
+}
+
+// getter returns a Go expression for the raw value of a numeric field of x.
+func getter(msg *protogen.Message, name protoreflect.Name) string {
+	for _, field := range msg.Fields {
+		if field.Desc.Name() != name {
+			continue
+		}
+		get := "x.Get" + field.GoName + "()"
+		switch field.Desc.Kind() {
+		case protoreflect.DoubleKind:
+			return get
+		case protoreflect.BoolKind:
+			return "promBool(" + get + ")"
+		default:
+			return "float64(" + get + ")"
+		}
+	}
+	panic("unknown field " + string(name))
+}
+
+// goExpr translates a derived metric expression into Go.
+func goExpr(msg *protogen.Message, e expr.Expr, nested bool) string {
+	switch e := e.(type) {
+	case expr.Num:
+		return strconv.FormatFloat(float64(e), 'g', -1, 64)
+	case expr.Var:
+		return getter(msg, protoreflect.Name(e))
+	case expr.Neg:
+		return "-" + goExpr(msg, e.X, true)
+	case expr.Op:
+		s := goExpr(msg, e.L, true) + " " + string(e.Op) + " " + goExpr(msg, e.R, true)
+		if nested {
+			return "(" + s + ")"
+		}
+		return s
+	default:
+		panic(fmt.Sprintf("unknown expr %T", e))
+	}
+}
+
+// literal returns a Go composite literal for an annotation message.
+func literal(g *protogen.GeneratedFile, msg *protogen.Message, m protoreflect.Message) string {
+	var b strings.Builder
+	b.WriteString("&" + g.QualifiedGoIdent(msg.GoIdent) + "{\n")
+	for _, field := range msg.Fields {
+		fd := m.Descriptor().Fields().ByName(field.Desc.Name())
+		if fd == nil || !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+		var s string
+		switch {
+		case fd.IsList():
+			elems := make([]string, v.List().Len())
+			for i := range elems {
+				elems[i] = scalar(g, field, v.List().Get(i))
+			}
+			s = "[]" + goType(g, field) + "{" + strings.Join(elems, ", ") + "}"
+		case fd.Kind() == protoreflect.MessageKind:
+			s = literal(g, field.Message, v.Message())
+		case field.Desc.HasOptionalKeyword():
+			s = g.QualifiedGoIdent(protoPackage.Ident(optionalFunc(fd.Kind()))) + "(" + scalar(g, field, v) + ")"
+		default:
+			s = scalar(g, field, v)
+		}
+		b.WriteString(field.GoName + ": " + s + ",\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func scalar(g *protogen.GeneratedFile, field *protogen.Field, v protoreflect.Value) string {
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.EnumKind:
+		for _, value := range field.Enum.Values {
+			if value.Desc.Number() == v.Enum() {
+				return g.QualifiedGoIdent(value.GoIdent)
+			}
+		}
+		return g.QualifiedGoIdent(field.Enum.GoIdent) + "(" + strconv.Itoa(int(v.Enum())) + ")"
+	default:
+		panic(fmt.Sprintf("unsupported annotation field %s", field.Desc.FullName()))
+	}
+}
+
+func goType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.Int64Kind:
+		return "int64"
+	default:
+		panic(fmt.Sprintf("unsupported annotation field %s", field.Desc.FullName()))
+	}
+}
+
+// optionalFunc returns the name of the proto package helper which returns a
+// pointer to a scalar of kind.
+func optionalFunc(kind protoreflect.Kind) string {
+	switch kind {
+	case protoreflect.StringKind:
+		return "String"
+	case protoreflect.BoolKind:
+		return "Bool"
+	case protoreflect.DoubleKind:
+		return "Float64"
+	case protoreflect.Int32Kind:
+		return "Int32"
+	case protoreflect.Int64Kind:
+		return "Int64"
+	default:
+		panic(fmt.Sprintf("unsupported optional kind %s", kind))
+	}
+}
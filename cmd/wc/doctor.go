@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/grid"
+	"github.com/R167/wallconnector/thermal"
+)
+
+// The status of a check. Statuses are ordered by severity.
+type status int
+
+const (
+	pass status = iota
+	warn
+	fail
+)
+
+func (s status) String() string {
+	return [...]string{"pass", "warn", "fail"}[s]
+}
+
+func (s status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+type check struct {
+	Name    string `json:"name"`
+	Status  status `json:"status"`
+	Message string `json:"message"`
+}
+
+type report struct {
+	Target string  `json:"target"`
+	Status status  `json:"status"`
+	Checks []check `json:"checks"`
+}
+
+func (r *report) add(name string, s status, format string, args ...any) {
+	r.Checks = append(r.Checks, check{Name: name, Status: s, Message: fmt.Sprintf(format, args...)})
+	r.Status = max(r.Status, s)
+}
+
+// threshold returns the status of v against warn and fail thresholds, which
+// are exceeded when v is above them, or below them if fail < warn.
+func threshold(v, warnAt, failAt float64) status {
+	if failAt < warnAt {
+		v, warnAt, failAt = -v, -warnAt, -failAt
+	}
+	switch {
+	case v >= failAt:
+		return fail
+	case v >= warnAt:
+		return warn
+	default:
+		return pass
+	}
+}
+
+func runDoctor(ctx context.Context, client *wallconnector.Client, args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	samples := fs.Int("samples", 10, "number of requests to measure latency with")
+	firmware := fs.String("firmware", "", "comma separated firmware versions known to be good")
+	limits := fs.String("temp-limits", "", "comma separated sensor=celsius overriding the estimated temperature limits, e.g. handle=65")
+	fs.Parse(args)
+	if *samples < 1 {
+		return errors.New("-samples must be at least 1")
+	}
+	sensors := thermal.Sensors
+	if *limits != "" {
+		var err error
+		if sensors, err = thermal.ParseLimits(*limits); err != nil {
+			return err
+		}
+	}
+
+	r := &report{Target: *target}
+	if !checkReachable(ctx, client, r, *samples) {
+		return printReport(r, *asJSON)
+	}
+
+	if wifi, err := client.Wifi(ctx); err != nil {
+		r.add("wifi", fail, "%v", err)
+	} else {
+		checkWifi(r, wifi)
+	}
+	if vitals, err := client.Vitals(ctx); err != nil {
+		r.add("vitals", fail, "%v", err)
+	} else {
+		checkGrid(r, vitals)
+		checkTemps(r, vitals, sensors)
+		checkAlerts(r, vitals)
+	}
+	if lifetime, err := client.Lifetime(ctx); err != nil {
+		r.add("lifetime", fail, "%v", err)
+	} else {
+		checkFoldbacks(r, lifetime)
+	}
+	if version, err := client.Version(ctx); err != nil {
+		r.add("firmware", fail, "%v", err)
+	} else {
+		checkFirmware(r, version, *firmware)
+	}
+	return printReport(r, *asJSON)
+}
+
+// checkReachable measures the latency of n requests, reporting whether any
+// succeeded.
+func checkReachable(ctx context.Context, client *wallconnector.Client, r *report, n int) bool {
+	var latencies []time.Duration
+	var lastErr error
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		if _, err := client.Version(ctx); err != nil {
+			lastErr = err
+			continue
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+	if len(latencies) == 0 {
+		r.add("reachable", fail, "%d of %d requests failed: %v", n, n, lastErr)
+		return false
+	}
+	if failed := n - len(latencies); failed > 0 {
+		r.add("reachable", warn, "%d of %d requests failed: %v", failed, n, lastErr)
+	} else {
+		r.add("reachable", pass, "%d of %d requests succeeded", n, n)
+	}
+
+	slices.Sort(latencies)
+	p95 := quantile(latencies, 0.95)
+	r.add("latency", threshold(p95.Seconds(), 0.5, 2),
+		"min %s, median %s, p95 %s, max %s",
+		quantile(latencies, 0), quantile(latencies, 0.5), p95, quantile(latencies, 1))
+	return true
+}
+
+// quantile returns the q quantile of sorted, by the nearest rank method,
+// rounded to the millisecond.
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	i := max(int(math.Ceil(q*float64(len(sorted))))-1, 0)
+	return sorted[i].Round(time.Millisecond)
+}
+
+func checkWifi(r *report, wifi *wallconnector.Wifi) {
+	if !wifi.GetWifiConnected() {
+		r.add("wifi", fail, "not connected")
+		return
+	}
+	r.add("wifi rssi", threshold(float64(wifi.GetWifiRssi()), -70, -80), "%d dBm", wifi.GetWifiRssi())
+	r.add("wifi snr", threshold(float64(wifi.GetWifiSnr()), 20, 10), "%d dB", wifi.GetWifiSnr())
+	if wifi.GetInternet() {
+		r.add("internet", pass, "connected")
+	} else {
+		r.add("internet", warn, "no internet connection, so firmware updates can't be downloaded")
+	}
+}
+
+func checkGrid(r *report, v *wallconnector.Vitals) {
+	if v.GetGridV() <= 0 {
+		r.add("grid voltage", fail, "no grid voltage")
+	} else {
+		nominal := grid.Nearest(v.GetGridV(), grid.NominalVoltages)
+		// ANSI C84.1 range A is within 5%, range B within 10%.
+		deviation := (v.GetGridV() - nominal) / nominal
+		r.add("grid voltage", threshold(math.Abs(deviation), 0.05, 0.10),
+			"%.1f V, %+.1f%% from %.0f V nominal", v.GetGridV(), deviation*100, nominal)
+	}
+
+	if v.GetGridHz() <= 0 {
+		r.add("grid frequency", fail, "no grid frequency")
+	} else {
+		nominal := grid.Nearest(v.GetGridHz(), grid.NominalFrequencies)
+		deviation := v.GetGridHz() - nominal
+		r.add("grid frequency", threshold(math.Abs(deviation), 0.1, 0.5),
+			"%.2f Hz, %+.2f Hz from %.0f Hz nominal", v.GetGridHz(), deviation, nominal)
+	}
+}
+
+func checkTemps(r *report, v *wallconnector.Vitals, sensors []thermal.Sensor) {
+	for _, sensor := range sensors {
+		temp := sensor.Temp(v)
+		r.add(sensor.Name+" temperature", threshold(temp, sensor.Warn, sensor.Limit),
+			"%.1f°C, %.1f°C below the %.0f°C limit", temp, sensor.Limit-temp, sensor.Limit)
+	}
+}
+
+func checkAlerts(r *report, v *wallconnector.Vitals) {
+	alerts := v.GetCurrentAlerts()
+	if len(alerts) == 0 {
+		r.add("alerts", pass, "none")
+		return
+	}
+	r.add("alerts", warn, "active alerts %s", strings.Trim(fmt.Sprint(alerts), "[]"))
+}
+
+func checkFoldbacks(r *report, l *wallconnector.Lifetime) {
+	foldbacks, starts := l.GetThermalFoldbackCount(), l.GetChargeStarts()
+	if starts == 0 {
+		r.add("thermal foldbacks", pass, "%d foldbacks, no charges yet", foldbacks)
+		return
+	}
+	rate := float64(foldbacks) / float64(starts)
+	r.add("thermal foldbacks", threshold(rate, 0.01, 0.10),
+		"%d foldbacks in %d charges (%.1f%%)", foldbacks, starts, rate*100)
+}
+
+func checkFirmware(r *report, v *wallconnector.Version, knownGood string) {
+	if knownGood == "" {
+		r.add("firmware", pass, "%s, no known good versions given", v.GetFirmwareVersion())
+		return
+	}
+	if slices.Contains(strings.Split(knownGood, ","), v.GetFirmwareVersion()) {
+		r.add("firmware", pass, "%s is known good", v.GetFirmwareVersion())
+		return
+	}
+	r.add("firmware", warn, "%s is not a known good version", v.GetFirmwareVersion())
+}
+
+// printReport prints r, returning an error if any check failed.
+func printReport(r *report, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	} else {
+		writeReport(os.Stdout, r)
+	}
+	if r.Status == fail {
+		return fmt.Errorf("checks failed for %s", r.Target)
+	}
+	return nil
+}
+
+func writeReport(w io.Writer, r *report) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r.Checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", strings.ToUpper(c.Status.String()), c.Name, c.Message)
+	}
+	fmt.Fprintf(tw, "\n%s\t%s\n", strings.ToUpper(r.Status.String()), r.Target)
+	tw.Flush()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreshold(t *testing.T) {
+	tests := []struct {
+		v, warn, fail float64
+		want          status
+	}{
+		// Higher is worse, e.g. latency.
+		{0.1, 0.5, 2, pass},
+		{0.5, 0.5, 2, warn},
+		{1, 0.5, 2, warn},
+		{2, 0.5, 2, fail},
+		// Lower is worse, e.g. RSSI.
+		{-60, -70, -80, pass},
+		{-70, -70, -80, warn},
+		{-79, -70, -80, warn},
+		{-85, -70, -80, fail},
+		{25, 20, 10, pass},
+		{10, 20, 10, fail},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, threshold(tt.v, tt.warn, tt.fail), fmt.Sprintf("threshold(%v, %v, %v)", tt.v, tt.warn, tt.fail))
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	ms := func(ds ...int) []time.Duration {
+		var out []time.Duration
+		for _, d := range ds {
+			out = append(out, time.Duration(d)*time.Millisecond)
+		}
+		return out
+	}
+	tests := []struct {
+		sorted []time.Duration
+		q      float64
+		want   time.Duration
+	}{
+		{ms(5), 0, 5 * time.Millisecond},
+		{ms(5), 0.95, 5 * time.Millisecond},
+		{ms(1, 2, 3, 4), 0, time.Millisecond},
+		{ms(1, 2, 3, 4), 0.5, 2 * time.Millisecond},
+		{ms(1, 2, 3, 4), 1, 4 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), 0.95, 10 * time.Millisecond},
+		{ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), 0.9, 9 * time.Millisecond},
+		{[]time.Duration{1400 * time.Microsecond}, 0.5, time.Millisecond},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, quantile(tt.sorted, tt.q), fmt.Sprintf("quantile(%v, %v)", tt.sorted, tt.q))
+	}
+}
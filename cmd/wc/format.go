@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// table writes aligned "key: value" rows.
+type table struct {
+	tw *tabwriter.Writer
+}
+
+func newTable(w io.Writer) *table {
+	return &table{tw: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)}
+}
+
+func (t *table) row(key, format string, args ...any) {
+	fmt.Fprintf(t.tw, "%s:\t%s\n", key, fmt.Sprintf(format, args...))
+}
+
+func (t *table) flush() {
+	t.tw.Flush()
+}
+
+// state summarizes what the charger is doing.
+func state(v *wallconnector.Vitals) string {
+	switch {
+	case v.GetContactorClosed() && v.GetVehicleCurrentA() > events.DefaultChargeThreshold:
+		return "Charging"
+	case v.GetVehicleConnected():
+		return "Vehicle connected"
+	default:
+		return "Idle"
+	}
+}
+
+func seconds(s float64) string {
+	return (time.Duration(s * float64(time.Second))).Round(time.Second).String()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func printStatus(w io.Writer, v *wallconnector.Vitals) {
+	t := newTable(w)
+	t.row("State", "%s (evse state %d)", state(v), v.GetEvseState())
+	t.row("Vehicle", "%.1f A, %.2f kW", v.GetVehicleCurrentA(), v.GetGridV()*v.GetVehicleCurrentA()/1000)
+	t.row("Grid", "%.1f V, %.2f Hz", v.GetGridV(), v.GetGridHz())
+	t.row("Wall", "A %.1f A %.0f V, B %.1f A %.0f V, C %.1f A %.0f V, N %.1f A",
+		v.GetCurrentAA(), v.GetVoltageAV(),
+		v.GetCurrentBA(), v.GetVoltageBV(),
+		v.GetCurrentCA(), v.GetVoltageCV(),
+		v.GetCurrentNA())
+	if v.GetVehicleConnected() {
+		t.row("Session", "%.2f kWh, %s", v.GetSessionEnergyWh()/1000, seconds(v.GetSessionS()))
+	}
+	t.row("Temperature", "PCBA %.1f°C, handle %.1f°C, MCU %.1f°C", v.GetPcbaTempC(), v.GetHandleTempC(), v.GetMcuTempC())
+	alerts := "none"
+	if len(v.GetCurrentAlerts()) > 0 {
+		alerts = strings.Trim(fmt.Sprint(v.GetCurrentAlerts()), "[]")
+	}
+	t.row("Alerts", "%s", alerts)
+	t.row("Uptime", "%s", seconds(v.GetUptimeS()))
+	t.flush()
+}
+
+func printLifetime(w io.Writer, l *wallconnector.Lifetime) {
+	t := newTable(w)
+	t.row("Energy", "%.1f kWh", float64(l.GetEnergyWh())/1000)
+	t.row("Charge starts", "%d", l.GetChargeStarts())
+	t.row("Charge time", "%s", seconds(float64(l.GetChargeTimeS())))
+	t.row("Connector cycles", "%d", l.GetConnectorCycles())
+	t.row("Contactor cycles", "%d (%d under load)", l.GetContactorCycles(), l.GetContactorCyclesLoaded())
+	t.row("Alerts", "%d", l.GetAlertCount())
+	t.row("Thermal foldbacks", "%d", l.GetThermalFoldbackCount())
+	t.row("Uptime", "%s", seconds(float64(l.GetUptimeS())))
+	t.row("Avg startup time", "%.1f", l.GetAvgStartupTime())
+	t.flush()
+}
+
+func printVersion(w io.Writer, v *wallconnector.Version) {
+	t := newTable(w)
+	t.row("Firmware", "%s", v.GetFirmwareVersion())
+	t.row("Part number", "%s", v.GetPartNumber())
+	t.row("Serial number", "%s", v.GetSerialNumber())
+	t.flush()
+}
+
+func printWifi(w io.Writer, wifi *wallconnector.Wifi) {
+	t := newTable(w)
+	t.row("Connected", "%s", yesNo(wifi.GetWifiConnected()))
+	t.row("Internet", "%s", yesNo(wifi.GetInternet()))
+	t.row("Signal", "%d%%, RSSI %d dBm, SNR %d dB", wifi.GetWifiSignalStrength(), wifi.GetWifiRssi(), wifi.GetWifiSnr())
+	t.flush()
+}
+
+// printFields prints the raw value of every field of m.
+func printFields(w io.Writer, m proto.Message) {
+	t := newTable(w)
+	fields := m.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		value := m.ProtoReflect().Get(field)
+		if !field.IsList() {
+			t.row(string(field.Name()), "%v", value)
+			continue
+		}
+		elems := make([]string, value.List().Len())
+		for j := range elems {
+			elems[j] = fmt.Sprint(value.List().Get(j))
+		}
+		t.row(string(field.Name()), "[%s]", strings.Join(elems, " "))
+	}
+	t.flush()
+}
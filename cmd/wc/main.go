@@ -0,0 +1,325 @@
+// A command line tool for checking on a wall connector.
+//
+// Usage:
+//
+//	wc [-target host:port] <command> [flags]
+//
+// Commands:
+//
+//	status    summary of the charger state, current, power, temperatures and alerts
+//	watch     live updating status
+//	lifetime  lifetime stats
+//	version   firmware version and serial number
+//	wifi      wifi status
+//	dump      everything, as text or with -json as JSON
+//	discover  find wall connectors on the local network
+//	doctor    check the health of the charger
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+var (
+	target  = flag.String("target", envOr("WC_TARGET", "localhost:8081"), "address of the wall connector, defaults to $WC_TARGET")
+	timeout = flag.Duration("timeout", 5*time.Second, "timeout for requests to the wall connector")
+)
+
+type command struct {
+	help string
+	run  func(ctx context.Context, client *wallconnector.Client, args []string) error
+}
+
+var commands = map[string]command{
+	"status":   {"summary of the charger state, current, power, temperatures and alerts", runStatus},
+	"watch":    {"live updating status", runWatch},
+	"lifetime": {"lifetime stats", runLifetime},
+	"version":  {"firmware version and serial number", runVersion},
+	"wifi":     {"wifi status", runWifi},
+	"dump":     {"everything, as text or with -json as JSON", runDump},
+	"discover": {"find wall connectors on the local network", runDiscover},
+	"doctor":   {"check the health of the charger", runDoctor},
+}
+
+// Order to list the commands in the usage.
+var commandOrder = []string{"status", "watch", "lifetime", "version", "wifi", "dump", "discover", "doctor"}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("wc: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		log.Printf("unknown command %q", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	client, err := wallconnector.NewClient(*target, wallconnector.WithTimeout(*timeout))
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := cmd.run(ctx, client, flag.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "usage: wc [flags] <command> [command flags]\n\ncommands:\n")
+	for _, name := range commandOrder {
+		fmt.Fprintf(w, "  %-9s %s\n", name, commands[name].help)
+	}
+	fmt.Fprintf(w, "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// noFlags parses the flags of a command which has none, for -h.
+func noFlags(name string, args []string) error {
+	return flag.NewFlagSet(name, flag.ExitOnError).Parse(args)
+}
+
+func runStatus(ctx context.Context, client *wallconnector.Client, args []string) error {
+	if err := noFlags("status", args); err != nil {
+		return err
+	}
+	vitals, err := client.Vitals(ctx)
+	if err != nil {
+		return err
+	}
+	printStatus(os.Stdout, vitals)
+	return nil
+}
+
+func runWatch(ctx context.Context, client *wallconnector.Client, args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", time.Second, "interval to poll at")
+	fs.Parse(args)
+	if *interval <= 0 {
+		return errors.New("-interval must be positive")
+	}
+
+	for sample := range client.Watch(ctx, *interval) {
+		// Clear the screen and move to the top left.
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("%s  %s\n\n", *target, sample.Time.Format(time.TimeOnly))
+		if sample.Err != nil {
+			fmt.Printf("error: %v\n", sample.Err)
+			continue
+		}
+		printStatus(os.Stdout, sample.Vitals)
+	}
+	return nil
+}
+
+func runLifetime(ctx context.Context, client *wallconnector.Client, args []string) error {
+	if err := noFlags("lifetime", args); err != nil {
+		return err
+	}
+	lifetime, err := client.Lifetime(ctx)
+	if err != nil {
+		return err
+	}
+	printLifetime(os.Stdout, lifetime)
+	return nil
+}
+
+func runVersion(ctx context.Context, client *wallconnector.Client, args []string) error {
+	if err := noFlags("version", args); err != nil {
+		return err
+	}
+	version, err := client.Version(ctx)
+	if err != nil {
+		return err
+	}
+	printVersion(os.Stdout, version)
+	return nil
+}
+
+func runWifi(ctx context.Context, client *wallconnector.Client, args []string) error {
+	if err := noFlags("wifi", args); err != nil {
+		return err
+	}
+	wifi, err := client.Wifi(ctx)
+	if err != nil {
+		return err
+	}
+	printWifi(os.Stdout, wifi)
+	return nil
+}
+
+// A dump of every endpoint of the wall connector.
+type dump struct {
+	Vitals   *wallconnector.Vitals   `json:"vitals"`
+	Lifetime *wallconnector.Lifetime `json:"lifetime"`
+	Version  *wallconnector.Version  `json:"version"`
+	Wifi     *wallconnector.Wifi     `json:"wifi"`
+}
+
+func runDump(ctx context.Context, client *wallconnector.Client, args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print as JSON")
+	fs.Parse(args)
+
+	var d dump
+	var err error
+	if d.Vitals, err = client.Vitals(ctx); err != nil {
+		return err
+	}
+	if d.Lifetime, err = client.Lifetime(ctx); err != nil {
+		return err
+	}
+	if d.Version, err = client.Version(ctx); err != nil {
+		return err
+	}
+	if d.Wifi, err = client.Wifi(ctx); err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+	for _, section := range []struct {
+		name  string
+		print func(io.Writer)
+	}{
+		{"Status", func(w io.Writer) { printStatus(w, d.Vitals) }},
+		{"Vitals", func(w io.Writer) { printFields(w, d.Vitals) }},
+		{"Lifetime", func(w io.Writer) { printLifetime(w, d.Lifetime) }},
+		{"Version", func(w io.Writer) { printVersion(w, d.Version) }},
+		{"Wifi", func(w io.Writer) { printWifi(w, d.Wifi) }},
+	} {
+		fmt.Printf("== %s ==\n", section.name)
+		section.print(os.Stdout)
+		fmt.Println()
+	}
+	return nil
+}
+
+func runDiscover(ctx context.Context, _ *wallconnector.Client, args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: wc discover [flags] [cidr ...]\n\nScans the given subnets, or the subnets of the local interfaces.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	probeTimeout := fs.Duration("probe-timeout", time.Second, "timeout for probing each host")
+	concurrency := fs.Int("concurrency", 64, "number of hosts to probe at once")
+	port := fs.Int("port", 0, "port to probe, defaults to 80")
+	fs.Parse(args)
+	if *concurrency < 1 {
+		return errors.New("-concurrency must be at least 1")
+	}
+
+	subnets := fs.Args()
+	if len(subnets) == 0 {
+		var err error
+		if subnets, err = localSubnets(); err != nil {
+			return err
+		}
+		if len(subnets) == 0 {
+			return errors.New("no local IPv4 subnets found, pass one to scan")
+		}
+	}
+
+	t := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "ADDRESS\tSERIAL\tPART\tFIRMWARE")
+	failed := 0
+	for _, cidr := range subnets {
+		log.Printf("scanning %s", cidr)
+		found, err := wallconnector.Discover(ctx, cidr,
+			wallconnector.WithProbeTimeout(*probeTimeout),
+			wallconnector.WithConcurrency(*concurrency),
+			wallconnector.WithPort(*port))
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			// Keep scanning the other subnets.
+			log.Print(err)
+			failed++
+			continue
+		}
+		for _, d := range found {
+			fmt.Fprintf(t, "%s\t%s\t%s\t%s\n", d.Addr, d.Version.GetSerialNumber(), d.Version.GetPartNumber(), d.Version.GetFirmwareVersion())
+		}
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	if failed == len(subnets) {
+		return errors.New("no subnets could be scanned")
+	}
+	return nil
+}
+
+// Largest local subnet to scan, the largest Discover scans.
+const maxLocalBits = 16
+
+// localSubnets returns the IPv4 subnets of the local interfaces which are up,
+// excluding loopback. Subnets too large to scan, e.g. the /8 of a VPN, are
+// narrowed to the /24 of the interface.
+func localSubnets() ([]string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var subnets []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || ipnet.IP.To4() == nil {
+				continue
+			}
+			subnets = append(subnets, localSubnet(iface.Name, ipnet))
+		}
+	}
+	return subnets, nil
+}
+
+func localSubnet(iface string, ipnet *net.IPNet) string {
+	mask := ipnet.Mask
+	if len(mask) == net.IPv6len {
+		mask = mask[12:]
+	}
+	if ones, bits := mask.Size(); bits-ones > maxLocalBits {
+		mask = net.CIDRMask(24, 32)
+		log.Printf("%s: %s is too large to scan, scanning its /24", iface, ipnet)
+	}
+	return (&net.IPNet{IP: ipnet.IP.To4().Mask(mask), Mask: mask}).String()
+}
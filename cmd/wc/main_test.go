@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalSubnet(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"192.168.1.23/24", "192.168.1.0/24"},
+		{"172.16.5.9/16", "172.16.0.0/16"},
+		// Too large to scan, so narrowed to the /24.
+		{"10.1.2.3/8", "10.1.2.0/24"},
+		{"100.64.7.1/10", "100.64.7.0/24"},
+	}
+	for _, tt := range tests {
+		ip, ipnet, err := net.ParseCIDR(tt.cidr)
+		assert.NoError(t, err)
+		ipnet.IP = ip.To4()
+		assert.Equal(t, tt.want, localSubnet("eth0", ipnet), tt.cidr)
+	}
+}
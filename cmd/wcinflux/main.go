@@ -0,0 +1,78 @@
+// Poll a wall connector and write its stats as InfluxDB line protocol.
+//
+// Without -url, each poll is printed to stdout, for use with the Telegraf
+// exec (or execd, with -interval) input. With -url, polls are written to the
+// InfluxDB v2 write API.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/influx"
+)
+
+var (
+	target   = flag.String("target", "localhost:8081", "address of the wall connector")
+	interval = flag.Duration("interval", 0, "interval to poll at, or 0 to poll once and exit")
+	timeout  = flag.Duration("timeout", 5*time.Second, "timeout for requests to the wall connector")
+	tag      = flag.String("tag", "", "value of the charger tag to add to every line")
+
+	url    = flag.String("url", "", "InfluxDB server to write to, e.g. http://localhost:8086")
+	org    = flag.String("org", "", "InfluxDB organization")
+	bucket = flag.String("bucket", "", "InfluxDB bucket")
+	token  = flag.String("token", os.Getenv("INFLUX_TOKEN"), "InfluxDB API token, defaults to $INFLUX_TOKEN")
+)
+
+func main() {
+	flag.Parse()
+
+	client, err := wallconnector.NewClient(*target, wallconnector.WithTimeout(*timeout))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tags map[string]string
+	if *tag != "" {
+		tags = map[string]string{"charger": *tag}
+	}
+
+	var writer *influx.Writer
+	if *url != "" {
+		writer, err = influx.NewWriter(*url, *org, *bucket, *token)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	poll := func(ctx context.Context) error {
+		samples, err := client.Samples(ctx)
+		if err != nil {
+			return err
+		}
+		if writer != nil {
+			return writer.Write(ctx, samples, tags, time.Now())
+		}
+		return influx.Encode(os.Stdout, samples, tags, time.Now())
+	}
+
+	ctx := context.Background()
+	if *interval == 0 {
+		if err := poll(ctx); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for ; ; <-ticker.C {
+		if err := poll(ctx); err != nil {
+			log.Print(err)
+		}
+	}
+}
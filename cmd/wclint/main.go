@@ -0,0 +1,108 @@
+// Lint the (prometheus) annotations in metrics.proto.
+//
+// By default the descriptors compiled into this binary are checked. To check
+// changes before regenerating code, pass a descriptor set built with
+//
+//	protoc --include_source_info --descriptor_set_out=metrics.pb metrics.proto
+//
+// Exits with status 1 if any issues were found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/internal/annotation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var descriptors = flag.String("descriptors", "", "descriptor set to lint instead of the compiled descriptors")
+
+func main() {
+	flag.Parse()
+
+	files := []protoreflect.FileDescriptor{wallconnector.File_metrics_proto}
+	if *descriptors != "" {
+		var err error
+		files, err = loadDescriptors(*descriptors)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	issues := 0
+	for _, file := range files {
+		for i := 0; i < file.Messages().Len(); i++ {
+			for _, issue := range annotation.Lint(file.Messages().Get(i)) {
+				fmt.Printf("%s: %s\n", position(file, issue.Element), issue)
+				issues++
+			}
+		}
+	}
+	if issues > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadDescriptors reads the files from a serialized FileDescriptorSet.
+func loadDescriptors(path string) ([]protoreflect.FileDescriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	reg, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+
+	var files []protoreflect.FileDescriptor
+	for _, f := range set.GetFile() {
+		fd, err := reg.FindFileByPath(f.GetName())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fd)
+	}
+	return files, nil
+}
+
+// position returns the file and line of el, if source info is available.
+func position(file protoreflect.FileDescriptor, el protoreflect.FullName) string {
+	desc, err := findDescriptor(file, el)
+	if err != nil {
+		return file.Path()
+	}
+	loc := file.SourceLocations().ByDescriptor(desc)
+	if loc.Path == nil {
+		return file.Path()
+	}
+	return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
+
+// findDescriptor finds a message or field by name. Derived metrics are
+// reported at their message.
+func findDescriptor(file protoreflect.FileDescriptor, el protoreflect.FullName) (protoreflect.Descriptor, error) {
+	for i := 0; i < file.Messages().Len(); i++ {
+		msg := file.Messages().Get(i)
+		if msg.FullName() == el {
+			return msg, nil
+		}
+		if msg.FullName() == el.Parent() {
+			if field := msg.Fields().ByName(el.Name()); field != nil {
+				return field, nil
+			}
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("%s not found", el)
+}
@@ -0,0 +1,87 @@
+// Poll a wall connector and publish its stats to MQTT, with Home Assistant
+// discovery so the charger appears in Home Assistant automatically.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/mqtt"
+)
+
+var (
+	target   = flag.String("target", "localhost:8081", "address of the wall connector")
+	interval = flag.Duration("interval", 10*time.Second, "interval to poll at")
+	timeout  = flag.Duration("timeout", 5*time.Second, "timeout for requests to the wall connector")
+
+	broker    = flag.String("broker", "localhost:1883", "address of the MQTT broker")
+	clientID  = flag.String("client-id", "wallconnector", "MQTT client id")
+	username  = flag.String("username", "", "MQTT username")
+	password  = flag.String("password", os.Getenv("MQTT_PASSWORD"), "MQTT password, defaults to $MQTT_PASSWORD")
+	prefix    = flag.String("prefix", "", "prefix of state topics, defaults to wallconnector/<serial number>")
+	discovery = flag.String("discovery-prefix", "homeassistant", "Home Assistant discovery prefix, or empty to disable discovery")
+)
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+
+	client, err := wallconnector.NewClient(*target, wallconnector.WithTimeout(*timeout))
+	if err != nil {
+		log.Fatal(err)
+	}
+	version, err := client.Version(ctx)
+	if err != nil {
+		log.Fatalf("fetching version: %v", err)
+	}
+
+	opts := []mqtt.PublisherConfig{mqtt.WithDiscoveryPrefix(*discovery)}
+	if *prefix != "" {
+		opts = append(opts, mqtt.WithPrefix(*prefix))
+	}
+
+	for {
+		if err := run(ctx, client, version, opts); err != nil {
+			log.Print(err)
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// run publishes until the connection to the broker fails.
+func run(ctx context.Context, client *wallconnector.Client, version *wallconnector.Version, opts []mqtt.PublisherConfig) error {
+	conn, err := mqtt.Dial(ctx, *broker,
+		mqtt.WithClientID(*clientID),
+		mqtt.WithCredentials(*username, *password),
+	)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	log.Printf("publishing %s to %s", version.GetSerialNumber(), *broker)
+
+	// Discovery configs are republished on every connection, in case the
+	// broker doesn't persist retained messages.
+	publisher := mqtt.NewPublisher(conn, version, opts...)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		samples, err := client.Samples(ctx)
+		if err != nil {
+			log.Printf("polling wall connector: %v", err)
+		} else if err := publisher.Publish(samples); err != nil {
+			return err
+		}
+
+		select {
+		case <-conn.Done():
+			return errors.New("lost connection to broker")
+		case <-ticker.C:
+		}
+	}
+}
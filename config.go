@@ -1,6 +1,7 @@
 package wallconnector
 
 import (
+	"context"
 	"net/http"
 	"time"
 )
@@ -13,6 +14,15 @@ type connectorOpts struct {
 
 	// Timeout for requests to the wallconnector API.
 	Timeout time.Duration
+
+	// Serial number to resolve the address of the wallconnector by, by
+	// scanning Subnet.
+	SerialNumber string
+	Subnet       string
+	DiscoverOpts []DiscoverConfig
+
+	// How often to check the address still belongs to the wallconnector.
+	VerifyInterval time.Duration
 }
 
 func WithTransport(t http.RoundTripper) func(*connectorOpts) {
@@ -26,3 +36,122 @@ func WithTimeout(t time.Duration) func(*connectorOpts) {
 		opts.Timeout = t
 	}
 }
+
+// WithSerialNumber resolves the address of the wallconnector by scanning the
+// subnet cidr for its serial number, and rescans when the device at the
+// address changes, e.g. after DHCP hands out a new lease. The address passed
+// to NewClient, if any, is tried first.
+func WithSerialNumber(serial, cidr string, discover ...DiscoverConfig) func(*connectorOpts) {
+	return func(opts *connectorOpts) {
+		opts.SerialNumber = serial
+		opts.Subnet = cidr
+		opts.DiscoverOpts = discover
+	}
+}
+
+// WithVerifyInterval sets how often the serial number of the wallconnector is
+// checked when resolving it with WithSerialNumber. Defaults to a minute.
+func WithVerifyInterval(d time.Duration) func(*connectorOpts) {
+	return func(opts *connectorOpts) {
+		opts.VerifyInterval = d
+	}
+}
+
+type CollectorConfig func(*collectorOpts)
+
+type collectorOpts struct {
+	// Path to persist the offsets of monotonic counters to.
+	CounterState string
+
+	// Serial number the wallconnector is expected to have.
+	SerialNumber string
+
+	// Vitals sampled between scrapes, for aggregated metrics, read until
+	// SamplesContext is done.
+	VitalsSamples  <-chan VitalsSample
+	SamplesContext context.Context
+
+	// Length of the windows of min/max aggregates.
+	AggregateWindow time.Duration
+}
+
+// WithCounterState persists the offsets used to keep monotonic counters from
+// going backwards to path, so they survive restarts of the exporter.
+func WithCounterState(path string) func(*collectorOpts) {
+	return func(opts *collectorOpts) {
+		opts.CounterState = path
+	}
+}
+
+// WithVitalsSamples aggregates the vitals received from samples, e.g. from
+// [Client.Watch], into the distributions of metrics annotated with aggregate.
+// Samples with errors are ignored. The collector reads samples until ctx is
+// done or the channel is closed.
+func WithVitalsSamples(ctx context.Context, samples <-chan VitalsSample) func(*collectorOpts) {
+	return func(opts *collectorOpts) {
+		opts.SamplesContext = ctx
+		opts.VitalsSamples = samples
+	}
+}
+
+// WithAggregateWindow sets the length of the windows the _min and _max
+// gauges of MINMAX aggregates cover. The last completed window is exported,
+// so every reader of the collector sees the same values. Defaults to a
+// minute.
+func WithAggregateWindow(d time.Duration) func(*collectorOpts) {
+	return func(opts *collectorOpts) {
+		opts.AggregateWindow = d
+	}
+}
+
+type DiscoverConfig func(*discoverOpts)
+
+type discoverOpts struct {
+	// Maximum number of hosts to probe at once.
+	Concurrency int
+
+	// Timeout for probing each host.
+	Timeout time.Duration
+
+	// Port to probe, or 0 for the default HTTP port.
+	Port int
+
+	// http.RoundTripper to probe hosts with.
+	Transport http.RoundTripper
+}
+
+func WithConcurrency(n int) func(*discoverOpts) {
+	return func(opts *discoverOpts) {
+		opts.Concurrency = n
+	}
+}
+
+func WithProbeTimeout(t time.Duration) func(*discoverOpts) {
+	return func(opts *discoverOpts) {
+		opts.Timeout = t
+	}
+}
+
+// WithDiscoverTransport sets the http.RoundTripper to probe hosts with.
+// Defaults to http.DefaultTransport.
+func WithDiscoverTransport(t http.RoundTripper) func(*discoverOpts) {
+	return func(opts *discoverOpts) {
+		opts.Transport = t
+	}
+}
+
+func WithPort(port int) func(*discoverOpts) {
+	return func(opts *discoverOpts) {
+		opts.Port = port
+	}
+}
+
+// WithExpectedSerial pins the serial number of the wallconnector. Every
+// scrape checks the device answering has it, and reports an error along with
+// wallconnector_serial_mismatch instead of its metrics when it doesn't. All
+// metrics are labelled with the serial number.
+func WithExpectedSerial(serial string) func(*collectorOpts) {
+	return func(opts *collectorOpts) {
+		opts.SerialNumber = serial
+	}
+}
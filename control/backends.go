@@ -0,0 +1,108 @@
+package control
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+
+	"github.com/R167/wallconnector"
+)
+
+// DryRun is a [CurrentLimiter] which only logs the limits it is asked to set,
+// to try out a configuration before handing it control of the charger.
+type DryRun struct {
+	// Logger to log to, or nil for the default logger.
+	Logger *log.Logger
+}
+
+func (d DryRun) SetCurrentLimit(ctx context.Context, amps float64) error {
+	logger := d.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Printf("dry run: setting current limit to %.0f A", amps)
+	return nil
+}
+
+// A Simulator simulates a vehicle charging on a site, for testing
+// controllers. It is a [CurrentLimiter], [SiteMeter] and [VitalsSource].
+//
+// The vehicle draws the lesser of its demand and the limit, and the limit
+// applies immediately. It is safe for concurrent use.
+type Simulator struct {
+	mu     sync.Mutex
+	gridV  float64
+	demand float64
+	load   float64
+	limit  float64
+}
+
+// NewSimulator creates a simulator with a grid at gridV, a vehicle which
+// draws up to demand amps and the rest of the site drawing load amps. The
+// vehicle is unlimited until a limit is set.
+func NewSimulator(gridV, demand, load float64) *Simulator {
+	return &Simulator{
+		gridV:  gridV,
+		demand: demand,
+		load:   load,
+		limit:  math.Inf(1),
+	}
+}
+
+func (s *Simulator) SetCurrentLimit(ctx context.Context, amps float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = amps
+	return nil
+}
+
+// Limit returns the limit last set.
+func (s *Simulator) Limit() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limit
+}
+
+// SetDemand sets the current the vehicle draws when unlimited.
+func (s *Simulator) SetDemand(amps float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.demand = amps
+}
+
+// SetLoad sets the current drawn by the rest of the site.
+func (s *Simulator) SetLoad(amps float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load = amps
+}
+
+func (s *Simulator) charging() float64 {
+	return math.Min(s.demand, s.limit)
+}
+
+func (s *Simulator) Vitals(ctx context.Context) (*wallconnector.Vitals, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.charging()
+	return &wallconnector.Vitals{
+		GridV:           s.gridV,
+		VehicleCurrentA: current,
+		ContactorClosed: current > 0,
+	}, nil
+}
+
+func (s *Simulator) SitePower(ctx context.Context) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return (s.load + s.charging()) * s.gridV, nil
+}
+
+var (
+	_ CurrentLimiter = DryRun{}
+	_ CurrentLimiter = (*Simulator)(nil)
+	_ SiteMeter      = (*Simulator)(nil)
+	_ VitalsSource   = (*Simulator)(nil)
+	_ VitalsSource   = (*wallconnector.Client)(nil)
+)
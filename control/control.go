@@ -0,0 +1,263 @@
+// Package control manages the load of a wall connector on the electrical
+// service of a site.
+//
+// The wall connector API is read only, so the [Controller] sets the charging
+// current through a [CurrentLimiter], e.g. the API of the vehicle or a smart
+// relay. It reads the vitals of the charger and the power drawn by the whole
+// site, and keeps the current of the charger plus the rest of the site under
+// the limit of the service.
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+// A CurrentLimiter limits the current the vehicle charges at.
+type CurrentLimiter interface {
+	// SetCurrentLimit limits the charging current to amps. Zero pauses
+	// charging.
+	SetCurrentLimit(ctx context.Context, amps float64) error
+}
+
+// A SiteMeter measures the power drawn by the whole site, including the
+// charger, e.g. from a meter on the service entrance.
+type SiteMeter interface {
+	// SitePower returns the power drawn by the site in watts.
+	SitePower(ctx context.Context) (float64, error)
+}
+
+// SiteMeterFunc adapts a function to a [SiteMeter].
+type SiteMeterFunc func(ctx context.Context) (float64, error)
+
+func (f SiteMeterFunc) SitePower(ctx context.Context) (float64, error) {
+	return f(ctx)
+}
+
+// A VitalsSource reads the vitals of the charger, e.g. a
+// [wallconnector.Client].
+type VitalsSource interface {
+	Vitals(ctx context.Context) (*wallconnector.Vitals, error)
+}
+
+// Minimum current to charge at, as below it J1772 requires the vehicle to
+// stop charging.
+const MinCurrent = 6
+
+type Config func(*controllerOpts)
+
+type controllerOpts struct {
+	// Current to keep in reserve below the service limit.
+	Margin float64
+
+	// Range of current to charge at.
+	Min, Max float64
+
+	// How long after lowering the limit to wait before raising it.
+	Holdoff time.Duration
+
+	// Limit to set when the charger or site can't be read.
+	Failsafe float64
+}
+
+// WithMargin keeps amps in reserve below the service limit, for loads which
+// start between polls. Defaults to 0.
+func WithMargin(amps float64) func(*controllerOpts) {
+	return func(opts *controllerOpts) {
+		opts.Margin = amps
+	}
+}
+
+// WithCurrentRange sets the range of current to charge at. Charging pauses
+// when less than min is available. Defaults to MinCurrent and 48A, the
+// maximum of the wall connector.
+func WithCurrentRange(min, max float64) func(*controllerOpts) {
+	return func(opts *controllerOpts) {
+		opts.Min = min
+		opts.Max = max
+	}
+}
+
+// WithHoldoff sets how long to wait after lowering the limit before raising
+// it again, so a cycling load doesn't make the charging current oscillate.
+// Defaults to a minute.
+func WithHoldoff(d time.Duration) func(*controllerOpts) {
+	return func(opts *controllerOpts) {
+		opts.Holdoff = d
+	}
+}
+
+// WithFailsafe sets the limit to set when the charger or site can't be read.
+// A lower limit, e.g. charging paused at the limit of the site, is kept.
+// Defaults to MinCurrent.
+func WithFailsafe(amps float64) func(*controllerOpts) {
+	return func(opts *controllerOpts) {
+		opts.Failsafe = amps
+	}
+}
+
+// A Decision is the limit computed by a step of the [Controller].
+type Decision struct {
+	Time time.Time `json:"time"`
+
+	GridV float64 `json:"grid_v"`
+	// Current drawn by the charger and by the rest of the site.
+	ChargerA float64 `json:"charger_a"`
+	OtherA   float64 `json:"other_a"`
+	// Current available to the charger under the service limit.
+	AvailableA float64 `json:"available_a"`
+
+	// The limit set, and whether it changed in this step.
+	Limit   float64 `json:"limit"`
+	Changed bool    `json:"changed"`
+	// Why the limit isn't the available current, if it isn't.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (d Decision) String() string {
+	s := fmt.Sprintf("limit %.0f A, charger %.1f A, other %.1f A, available %.1f A",
+		d.Limit, d.ChargerA, d.OtherA, d.AvailableA)
+	if d.Reason != "" {
+		s += " (" + d.Reason + ")"
+	}
+	return s
+}
+
+// A Controller keeps a charger within the limit of the service of its site.
+// It is not safe for concurrent use.
+type Controller struct {
+	vitals  VitalsSource
+	site    SiteMeter
+	limiter CurrentLimiter
+	// Service limit in amps.
+	limit float64
+	opts  *controllerOpts
+
+	// The limit last set, NaN until the first is.
+	current float64
+	// When the limit was last lowered.
+	lowered time.Time
+
+	now func() time.Time
+}
+
+// NewController creates a controller keeping the current drawn by the site
+// under serviceLimit amps by limiting the charger with limiter.
+func NewController(vitals VitalsSource, site SiteMeter, limiter CurrentLimiter, serviceLimit float64, opts ...Config) *Controller {
+	o := &controllerOpts{
+		Min:      MinCurrent,
+		Max:      48,
+		Holdoff:  time.Minute,
+		Failsafe: MinCurrent,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Controller{
+		vitals:  vitals,
+		site:    site,
+		limiter: limiter,
+		limit:   serviceLimit,
+		opts:    o,
+		current: math.NaN(),
+		now:     time.Now,
+	}
+}
+
+// Step reads the charger and site once and sets the limit of the charger if
+// it changed. When either can't be read, the limit is lowered to the
+// failsafe and the error returned.
+func (c *Controller) Step(ctx context.Context) (Decision, error) {
+	d := Decision{Time: c.now()}
+	if err := c.measure(ctx, &d); err != nil {
+		d.Limit, d.Reason = c.opts.Failsafe, "failsafe"
+		// Never raise the limit without knowing the load of the site. The
+		// current is NaN until the first limit is set.
+		if c.current < d.Limit {
+			d.Limit = c.current
+		}
+		if setErr := c.set(ctx, &d); setErr != nil {
+			err = errors.Join(err, setErr)
+		}
+		return d, err
+	}
+
+	d.AvailableA = c.limit - c.opts.Margin - d.OtherA
+	target := math.Floor(math.Min(d.AvailableA, c.opts.Max))
+	switch {
+	case target < c.opts.Min:
+		target, d.Reason = 0, "below minimum current"
+	case target == c.opts.Max:
+		d.Reason = "maximum current"
+	}
+	if target > c.current && d.Time.Sub(c.lowered) < c.opts.Holdoff {
+		target, d.Reason = c.current, "holdoff"
+	}
+	d.Limit = target
+	return d, c.set(ctx, &d)
+}
+
+// measure reads the current drawn by the charger and the rest of the site.
+func (c *Controller) measure(ctx context.Context, d *Decision) error {
+	v, err := c.vitals.Vitals(ctx)
+	if err != nil {
+		return fmt.Errorf("reading vitals: %w", err)
+	}
+	watts, err := c.site.SitePower(ctx)
+	if err != nil {
+		return fmt.Errorf("reading site power: %w", err)
+	}
+	d.GridV = v.GetGridV()
+	if d.GridV <= 0 {
+		return errors.New("no grid voltage")
+	}
+	d.ChargerA = v.GetVehicleCurrentA()
+	// The meter may lag the charger, so the rest of the site can appear to
+	// draw less than nothing.
+	d.OtherA = math.Max(0, watts/d.GridV-d.ChargerA)
+	return nil
+}
+
+// set sets the limit of d if it differs from the current limit.
+func (c *Controller) set(ctx context.Context, d *Decision) error {
+	if d.Limit == c.current {
+		return nil
+	}
+	if err := c.limiter.SetCurrentLimit(ctx, d.Limit); err != nil {
+		return fmt.Errorf("setting current limit: %w", err)
+	}
+	if d.Limit < c.current {
+		c.lowered = d.Time
+	}
+	c.current = d.Limit
+	d.Changed = true
+	return nil
+}
+
+// Run steps the controller every interval until ctx is done, logging changes
+// of the limit and errors.
+func (c *Controller) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		d, err := c.Step(ctx)
+		if err != nil {
+			log.Printf("load control: %v", err)
+		}
+		if d.Changed {
+			log.Printf("load control: %s", d)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestController(t *testing.T) {
+	ctx := context.Background()
+	sim := NewSimulator(240, 40, 20)
+	c := NewController(sim, sim, sim, 100, WithMargin(10), WithHoldoff(time.Minute))
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	// 100A service, 10A margin and 20A of other load leaves 70A, more than
+	// the charger can deliver.
+	d, err := c.Step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, d.OtherA)
+	assert.Equal(t, 70.0, d.AvailableA)
+	assert.Equal(t, 48.0, d.Limit)
+	assert.True(t, d.Changed)
+	assert.Equal(t, 48.0, sim.Limit())
+
+	// A 55.5A load starts, leaving 34.5A.
+	sim.SetLoad(55.5)
+	now = now.Add(5 * time.Second)
+	d, err = c.Step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 34.0, d.Limit)
+	assert.Equal(t, 34.0, sim.Limit())
+
+	// The load stops, but the limit is held until the holdoff passes.
+	sim.SetLoad(20)
+	now = now.Add(5 * time.Second)
+	d, err = c.Step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 34.0, d.Limit)
+	assert.Equal(t, "holdoff", d.Reason)
+	assert.False(t, d.Changed)
+
+	now = now.Add(time.Minute)
+	d, err = c.Step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 48.0, d.Limit)
+	assert.True(t, d.Changed)
+
+	// Too little for the minimum current pauses charging.
+	sim.SetLoad(86)
+	now = now.Add(5 * time.Second)
+	d, err = c.Step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, d.Limit)
+	assert.Equal(t, "below minimum current", d.Reason)
+	assert.Equal(t, 0.0, sim.Limit())
+}
+
+func TestControllerFailsafe(t *testing.T) {
+	ctx := context.Background()
+	sim := NewSimulator(240, 32, 0)
+	meter := SiteMeterFunc(func(context.Context) (float64, error) {
+		return 0, errors.New("meter offline")
+	})
+	c := NewController(sim, meter, sim, 100, WithFailsafe(10))
+
+	d, err := c.Step(ctx)
+	assert.ErrorContains(t, err, "meter offline")
+	assert.Equal(t, 10.0, d.Limit)
+	assert.Equal(t, "failsafe", d.Reason)
+	assert.Equal(t, 10.0, sim.Limit())
+}
+
+func TestControllerFailsafeKeepsPause(t *testing.T) {
+	ctx := context.Background()
+	sim := NewSimulator(240, 32, 95)
+	var meterErr error
+	meter := SiteMeterFunc(func(ctx context.Context) (float64, error) {
+		if meterErr != nil {
+			return 0, meterErr
+		}
+		return sim.SitePower(ctx)
+	})
+	c := NewController(sim, meter, sim, 100)
+
+	d, err := c.Step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, d.Limit)
+
+	meterErr = errors.New("meter offline")
+	d, err = c.Step(ctx)
+	assert.ErrorContains(t, err, "meter offline")
+	assert.Equal(t, 0.0, d.Limit)
+	assert.Equal(t, "failsafe", d.Reason)
+	assert.False(t, d.Changed)
+	assert.Equal(t, 0.0, sim.Limit())
+}
+
+type noGrid struct{}
+
+func (noGrid) Vitals(context.Context) (*wallconnector.Vitals, error) {
+	return &wallconnector.Vitals{}, nil
+}
+
+func TestControllerNoGrid(t *testing.T) {
+	sim := NewSimulator(240, 32, 0)
+	c := NewController(noGrid{}, sim, sim, 100)
+	d, err := c.Step(context.Background())
+	assert.ErrorContains(t, err, "no grid voltage")
+	assert.Equal(t, float64(MinCurrent), d.Limit)
+}
+
+func TestDryRun(t *testing.T) {
+	var buf bytes.Buffer
+	sim := NewSimulator(240, 32, 0)
+	c := NewController(sim, sim, DryRun{Logger: log.New(&buf, "", 0)}, 40)
+
+	d, err := c.Step(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, d.Limit)
+	assert.Equal(t, "dry run: setting current limit to 40 A\n", buf.String())
+	// The vehicle isn't limited.
+	assert.Equal(t, 32.0, d.ChargerA)
+	assert.Equal(t, "limit 40 A, charger 32.0 A, other 0.0 A, available 40.0 A", d.String())
+}
@@ -0,0 +1,177 @@
+package wallconnector
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// int32Wrap is the amount an int32 counter goes backwards by when it overflows.
+const int32Wrap = 1 << 32
+
+// counterGuard keeps monotonic counters from going backwards when the device
+// resets them (e.g. a firmware update) or they overflow. When a regression is
+// detected, the last value seen is added to an offset which is applied to all
+// future values of the counter.
+//
+// The offsets are optionally persisted to disk so exported counters stay
+// monotonic across restarts of the exporter. They belong to the device with
+// serial, so are discarded when the exporter reaches another device rather
+// than taking its lower counters as a reset.
+type counterGuard struct {
+	path   string
+	resets *prometheus.CounterVec
+
+	mu     sync.Mutex
+	dirty  bool
+	serial string
+	states map[string]*counterState
+}
+
+type counterState struct {
+	Last   float64 `json:"last"`
+	Offset float64 `json:"offset"`
+}
+
+// counterFile is the persisted state. Older versions persisted only the
+// counters, without the serial number.
+type counterFile struct {
+	Serial   string                   `json:"serial"`
+	Counters map[string]*counterState `json:"counters"`
+}
+
+func newCounterGuard(path string, labels prometheus.Labels) *counterGuard {
+	g := &counterGuard{
+		path:   path,
+		states: make(map[string]*counterState),
+		resets: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "wallconnector",
+			Name:      "counter_reset_events_total",
+			Help:      "Number of times a monotonic counter reported by the device went backwards.",
+
+			ConstLabels: labels,
+		}, []string{"metric", "reason"}),
+	}
+	if path == "" {
+		return g
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return g
+	} else if err != nil {
+		log.Printf("reading counter state: %v", err)
+		return g
+	}
+	var file counterFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		log.Printf("parsing counter state %s: %v", path, err)
+		return g
+	}
+	if file.Counters == nil {
+		// The serial number is adopted from the first device seen.
+		if err := json.Unmarshal(data, &g.states); err != nil {
+			log.Printf("parsing counter state %s: %v", path, err)
+		}
+		return g
+	}
+	g.serial, g.states = file.Serial, file.Counters
+	return g
+}
+
+// device sets the serial number of the device the counters are read from,
+// discarding the state of any other device.
+func (g *counterGuard) device(serial string) {
+	if g == nil || serial == "" {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if serial == g.serial {
+		return
+	}
+	if g.serial != "" {
+		log.Printf("counter state is for %s, discarding it for %s", g.serial, serial)
+		g.states = make(map[string]*counterState)
+	}
+	g.serial = serial
+	g.dirty = true
+}
+
+// adjust returns raw plus the offset for the counter identified by key,
+// updating the offset if raw is lower than the last value seen. If wrap is
+// non-zero, drops of more than half of wrap are treated as an overflow rather
+// than a reset.
+func (g *counterGuard) adjust(key, name string, raw, wrap float64) float64 {
+	if g == nil {
+		return raw
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state, ok := g.states[key]
+	if !ok {
+		state = &counterState{Last: raw}
+		g.states[key] = state
+	}
+	if raw < state.Last {
+		if wrap > 0 && state.Last-raw > wrap/2 {
+			state.Offset += wrap
+			g.resets.WithLabelValues(name, "overflow").Inc()
+		} else {
+			state.Offset += state.Last
+			g.resets.WithLabelValues(name, "reset").Inc()
+		}
+	}
+	if !ok || raw != state.Last {
+		state.Last = raw
+		g.dirty = true
+	}
+	return raw + state.Offset
+}
+
+// save persists the counter state if it changed since the last save.
+func (g *counterGuard) save() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.path == "" || !g.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(counterFile{Serial: g.serial, Counters: g.states})
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash never leaves a partial state.
+	tmp, err := os.CreateTemp(filepath.Dir(g.path), filepath.Base(g.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), g.path); err != nil {
+		return err
+	}
+	g.dirty = false
+	return nil
+}
+
+func (g *counterGuard) Describe(ch chan<- *prometheus.Desc) {
+	g.resets.Describe(ch)
+}
+
+func (g *counterGuard) Collect(ch chan<- prometheus.Metric) {
+	g.resets.Collect(ch)
+}
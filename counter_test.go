@@ -0,0 +1,70 @@
+package wallconnector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterGuard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters.json")
+	g := newCounterGuard(path, nil)
+
+	assert.Equal(t, 100.0, g.adjust("a", "a_total", 100, 0))
+	assert.Equal(t, 150.0, g.adjust("a", "a_total", 150, 0))
+	// Firmware reset.
+	assert.Equal(t, 160.0, g.adjust("a", "a_total", 10, 0))
+	assert.Equal(t, 170.0, g.adjust("a", "a_total", 20, 0))
+	assert.Equal(t, 1.0, testutil.ToFloat64(g.resets.WithLabelValues("a_total", "reset")))
+
+	// int32 overflow.
+	assert.Equal(t, 2147483640.0, g.adjust("b", "b_total", 2147483640, int32Wrap))
+	assert.Equal(t, 2147483650.0, g.adjust("b", "b_total", -2147483646, int32Wrap))
+	assert.Equal(t, 1.0, testutil.ToFloat64(g.resets.WithLabelValues("b_total", "overflow")))
+
+	// Offsets survive a restart.
+	assert.NoError(t, g.save())
+	g = newCounterGuard(path, nil)
+	assert.Equal(t, 175.0, g.adjust("a", "a_total", 25, 0))
+	assert.Equal(t, 0.0, testutil.ToFloat64(g.resets.WithLabelValues("a_total", "reset")))
+}
+
+func TestCounterGuardNil(t *testing.T) {
+	var g *counterGuard
+	assert.Equal(t, 5.0, g.adjust("a", "a_total", 5, 0))
+}
+
+func TestCounterGuardDeviceSwap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters.json")
+	g := newCounterGuard(path, nil)
+	g.device("A1")
+	assert.Equal(t, 1000.0, g.adjust("a", "a_total", 1000, 0))
+	assert.NoError(t, g.save())
+
+	// The same device after a restart keeps its offsets.
+	g = newCounterGuard(path, nil)
+	g.device("A1")
+	assert.Equal(t, 1010.0, g.adjust("a", "a_total", 10, 0))
+	assert.NoError(t, g.save())
+
+	// Another device at the address doesn't inherit them.
+	g = newCounterGuard(path, nil)
+	g.device("B2")
+	assert.Equal(t, 5.0, g.adjust("a", "a_total", 5, 0))
+	assert.Equal(t, 0.0, testutil.ToFloat64(g.resets.WithLabelValues("a_total", "reset")))
+	assert.NoError(t, g.save())
+	g = newCounterGuard(path, nil)
+	assert.Equal(t, "B2", g.serial)
+}
+
+func TestCounterGuardLegacyState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"a": {"last": 100, "offset": 50}}`), 0o644))
+
+	g := newCounterGuard(path, nil)
+	g.device("A1")
+	assert.Equal(t, 160.0, g.adjust("a", "a_total", 110, 0))
+}
@@ -0,0 +1,117 @@
+package wallconnector
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// A FieldChange is a field which changed between two messages.
+type FieldChange struct {
+	Field protoreflect.FieldDescriptor
+	Old   protoreflect.Value
+	New   protoreflect.Value
+}
+
+// String returns the change as e.g. "grid_v: 240 -> 242".
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field.Name(), formatValue(c.Field, c.Old), formatValue(c.Field, c.New))
+}
+
+func formatValue(field protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if !field.IsList() {
+		return fmt.Sprint(v)
+	}
+	elems := make([]string, v.List().Len())
+	for i := range elems {
+		elems[i] = fmt.Sprint(v.List().Get(i))
+	}
+	return "[" + strings.Join(elems, " ") + "]"
+}
+
+// Diff returns the fields which changed from prev to next, in field order.
+// A nil prev is treated as empty, so every set field of next is a change.
+//
+// Numeric fields only change when they move by at least the deadband of their
+// annotation. To catch slow drift, pass the message the last change was
+// reported for as prev, rather than the previous poll.
+func Diff(prev, next *Vitals) []FieldChange {
+	return DiffMessage(prev, next)
+}
+
+// DiffMessage is like [Diff] for any annotated message. prev and next must be
+// the same type.
+func DiffMessage(prev, next proto.Message) []FieldChange {
+	p, n := prev.ProtoReflect(), next.ProtoReflect()
+	if p.Descriptor() != n.Descriptor() {
+		panic(fmt.Sprintf("diffing %s and %s", p.Descriptor().FullName(), n.Descriptor().FullName()))
+	}
+
+	var changes []FieldChange
+	fields := n.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		old, new := p.Get(field), n.Get(field)
+		if !fieldChanged(field, old, new) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, Old: old, New: new})
+	}
+	return changes
+}
+
+// fieldChanged reports whether a field changed by more than its deadband.
+func fieldChanged(field protoreflect.FieldDescriptor, old, new protoreflect.Value) bool {
+	if o, ok := fieldValue(field, old); ok {
+		n, _ := fieldValue(field, new)
+		if o == n {
+			return false
+		}
+		opts := field.Options().(*descriptorpb.FieldOptions)
+		ext, _ := proto.GetExtension(opts, E_Prometheus).(*Metric)
+		return math.Abs(n-o) >= ext.GetDeadband()
+	}
+
+	if field.IsList() {
+		o, n := old.List(), new.List()
+		if o.Len() != n.Len() {
+			return true
+		}
+		for i := 0; i < o.Len(); i++ {
+			if !scalarEqual(field, o.Get(i), n.Get(i)) {
+				return true
+			}
+		}
+		return false
+	}
+	if field.IsMap() {
+		o, n := old.Map(), new.Map()
+		if o.Len() != n.Len() {
+			return true
+		}
+		changed := false
+		o.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			changed = !n.Has(k) || !scalarEqual(field.MapValue(), v, n.Get(k))
+			return !changed
+		})
+		return changed
+	}
+	return !scalarEqual(field, old, new)
+}
+
+// scalarEqual reports whether two values of a singular field are equal.
+func scalarEqual(field protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return proto.Equal(a.Message().Interface(), b.Message().Interface())
+	case protoreflect.BytesKind:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
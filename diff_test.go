@@ -0,0 +1,37 @@
+package wallconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func changeStrings(changes []FieldChange) []string {
+	var s []string
+	for _, c := range changes {
+		s = append(s, c.String())
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	prev := &Vitals{GridV: 240, VoltageAV: 120, PcbaTempC: 30, UptimeS: 10, CurrentAlerts: []int32{1}}
+	next := &Vitals{GridV: 240.8, VoltageAV: 121, PcbaTempC: 30.2, UptimeS: 15, VehicleConnected: true, CurrentAlerts: []int32{1, 2}}
+
+	assert.Equal(t, []string{
+		"vehicle_connected: false -> true",
+		"voltageA_v: 120 -> 121",
+		"uptime_s: 10 -> 15",
+		"current_alerts: [1] -> [1 2]",
+	}, changeStrings(Diff(prev, next)))
+
+	assert.Empty(t, Diff(next, next))
+	assert.Equal(t, []string{"grid_v: 0 -> 240"}, changeStrings(Diff(nil, &Vitals{GridV: 240})))
+}
+
+func TestDiffMessage(t *testing.T) {
+	changes := DiffMessage(&Wifi{WifiRssi: -60}, &Wifi{WifiRssi: -60, WifiConnected: true})
+	assert.Equal(t, []string{"wifi_connected: false -> true"}, changeStrings(changes))
+
+	assert.Panics(t, func() { DiffMessage(&Wifi{}, &Vitals{}) })
+}
@@ -0,0 +1,108 @@
+package wallconnector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Largest subnet Discover will scan, to avoid accidentally scanning a /8.
+const maxDiscoverBits = 16
+
+// A Discovered wallconnector found by [Discover].
+type Discovered struct {
+	// Address of the wallconnector, suitable for [NewClient].
+	Addr    string
+	Version *Version
+}
+
+// Discover scans the hosts of the IPv4 subnet cidr (e.g. 192.168.1.0/24) for
+// wallconnectors, returning those which answer with a valid version in
+// address order.
+func Discover(ctx context.Context, cidr string, opts ...DiscoverConfig) ([]Discovered, error) {
+	o := &discoverOpts{
+		Concurrency: 64,
+		Timeout:     time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Concurrency < 1 {
+		return nil, fmt.Errorf("discover: concurrency must be at least 1, got %d", o.Concurrency)
+	}
+
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, err
+	}
+	prefix = prefix.Masked()
+	if !prefix.Addr().Is4() {
+		return nil, fmt.Errorf("discover: %s is not an IPv4 subnet", cidr)
+	}
+	if 32-prefix.Bits() > maxDiscoverBits {
+		return nil, fmt.Errorf("discover: %s is larger than a /%d", cidr, 32-maxDiscoverBits)
+	}
+
+	httpClient := &http.Client{Transport: o.Transport, Timeout: o.Timeout}
+	addrs := hosts(prefix)
+	// Indexed by host, so results are in address order.
+	results := make([]*Version, len(addrs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, o.Concurrency)
+	for i, ip := range addrs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, ip netip.Addr) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			c := &Client{addr: discoverAddr(ip, o.Port), client: httpClient}
+			version, err := c.Version(ctx)
+			if err != nil || version.GetSerialNumber() == "" || version.GetPartNumber() == "" {
+				return
+			}
+			results[i] = version
+		}(i, ip)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var found []Discovered
+	for i, version := range results {
+		if version != nil {
+			found = append(found, Discovered{Addr: discoverAddr(addrs[i], o.Port), Version: version})
+		}
+	}
+	return found, nil
+}
+
+// hosts returns the host addresses of prefix, excluding the network and
+// broadcast addresses of subnets which have them.
+func hosts(prefix netip.Prefix) []netip.Addr {
+	var addrs []netip.Addr
+	for ip := prefix.Addr(); prefix.Contains(ip); ip = ip.Next() {
+		addrs = append(addrs, ip)
+	}
+	if prefix.Bits() < 31 {
+		addrs = addrs[1 : len(addrs)-1]
+	}
+	return addrs
+}
+
+func discoverAddr(ip netip.Addr, port int) string {
+	if port == 0 {
+		return ip.String()
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(port))
+}
@@ -0,0 +1,54 @@
+package wallconnector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscover(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, versionPath, r.URL.Path)
+		w.Write([]byte(`{"firmware_version": "23.44.0", "part_number": "1529455-02-D", "serial_number": "PGT123"}`))
+	}))
+	defer srv.Close()
+	port, _ := strconv.Atoi(srv.URL[len("http://127.0.0.1:"):])
+
+	found, err := Discover(context.Background(), "127.0.0.1/32", WithPort(port))
+	if assert.NoError(t, err) && assert.Len(t, found, 1) {
+		assert.Equal(t, srv.URL[len("http://"):], found[0].Addr)
+		assert.Equal(t, "PGT123", found[0].Version.GetSerialNumber())
+	}
+
+	_, err = Discover(context.Background(), "10.0.0.0/8")
+	assert.Error(t, err)
+	_, err = Discover(context.Background(), "::1/128")
+	assert.Error(t, err)
+}
+
+func TestDiscoverInvalid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "some other device"}`))
+	}))
+	defer srv.Close()
+	port, _ := strconv.Atoi(srv.URL[len("http://127.0.0.1:"):])
+
+	found, err := Discover(context.Background(), "127.0.0.1/32", WithPort(port))
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = Discover(context.Background(), "127.0.0.1/32", WithPort(port), WithConcurrency(0))
+	assert.EqualError(t, err, "discover: concurrency must be at least 1, got 0")
+}
+
+func TestHosts(t *testing.T) {
+	addrs := hosts(netip.MustParsePrefix("192.168.1.0/30"))
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("192.168.1.2")}, addrs)
+	assert.Len(t, hosts(netip.MustParsePrefix("192.168.1.0/31")), 2)
+	assert.Len(t, hosts(netip.MustParsePrefix("192.168.1.0/24")), 254)
+}
@@ -0,0 +1,115 @@
+// Package events derives charger events, like a vehicle connecting or a
+// charge finishing, from consecutive polls of a wall connector.
+package events
+
+import (
+	"slices"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+// Type is the type of an event.
+type Type string
+
+const (
+	VehicleConnected    Type = "vehicle_connected"
+	VehicleDisconnected Type = "vehicle_disconnected"
+	ChargeStarted       Type = "charge_started"
+	ChargeStopped       Type = "charge_stopped"
+	AlertRaised         Type = "alert_raised"
+	AlertCleared        Type = "alert_cleared"
+	ThermalFoldback     Type = "thermal_foldback"
+	Rebooted            Type = "rebooted"
+)
+
+// An Event is something which happened to the charger between two polls.
+type Event struct {
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+
+	// The alert raised or cleared, for alert events.
+	Alert int32 `json:"alert,omitempty"`
+
+	// The vitals the event was detected in, if any.
+	Vitals *wallconnector.Vitals `json:"vitals,omitempty"`
+}
+
+// DefaultChargeThreshold is the vehicle current above which a vehicle is
+// considered to be charging.
+const DefaultChargeThreshold = 1.0
+
+// A Detector detects events from consecutive polls of a single charger.
+type Detector struct {
+	// Vehicle current in amperes above which a vehicle is charging.
+	ChargeThreshold float64
+
+	prev      *wallconnector.Vitals
+	foldbacks int32
+	lifetime  bool
+}
+
+// NewDetector creates a detector with the default charge threshold.
+func NewDetector() *Detector {
+	return &Detector{ChargeThreshold: DefaultChargeThreshold}
+}
+
+// Vitals returns the events between the previous vitals and v, polled at t.
+// The first call only records v.
+func (d *Detector) Vitals(t time.Time, v *wallconnector.Vitals) []Event {
+	prev := d.prev
+	d.prev = v
+	if prev == nil {
+		return nil
+	}
+
+	var events []Event
+	add := func(typ Type) {
+		events = append(events, Event{Type: typ, Time: t, Vitals: v})
+	}
+
+	if v.GetUptimeS() < prev.GetUptimeS() {
+		add(Rebooted)
+	}
+
+	if !prev.GetVehicleConnected() && v.GetVehicleConnected() {
+		add(VehicleConnected)
+	}
+
+	wasCharging := prev.GetVehicleCurrentA() > d.ChargeThreshold
+	charging := v.GetVehicleCurrentA() > d.ChargeThreshold
+	if !wasCharging && charging {
+		add(ChargeStarted)
+	} else if wasCharging && !charging {
+		add(ChargeStopped)
+	}
+
+	if prev.GetVehicleConnected() && !v.GetVehicleConnected() {
+		add(VehicleDisconnected)
+	}
+
+	for _, alert := range v.GetCurrentAlerts() {
+		if !slices.Contains(prev.GetCurrentAlerts(), alert) {
+			events = append(events, Event{Type: AlertRaised, Time: t, Alert: alert, Vitals: v})
+		}
+	}
+	for _, alert := range prev.GetCurrentAlerts() {
+		if !slices.Contains(v.GetCurrentAlerts(), alert) {
+			events = append(events, Event{Type: AlertCleared, Time: t, Alert: alert, Vitals: v})
+		}
+	}
+	return events
+}
+
+// Lifetime returns the events between the previous lifetime stats and l,
+// polled at t. Thermal foldbacks are only reported by the lifetime stats, so
+// they need to be polled as well to detect them.
+func (d *Detector) Lifetime(t time.Time, l *wallconnector.Lifetime) []Event {
+	count := l.GetThermalFoldbackCount()
+	prev, ok := d.foldbacks, d.lifetime
+	d.foldbacks, d.lifetime = count, true
+	if !ok || count <= prev {
+		return nil
+	}
+	return []Event{{Type: ThermalFoldback, Time: t, Vitals: d.prev}}
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+)
+
+func types(events []Event) []Type {
+	var types []Type
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestDetector(t *testing.T) {
+	d := NewDetector()
+	now := time.Now()
+
+	assert.Empty(t, d.Vitals(now, &wallconnector.Vitals{UptimeS: 10}))
+	assert.Equal(t, []Type{VehicleConnected}, types(d.Vitals(now, &wallconnector.Vitals{UptimeS: 20, VehicleConnected: true})))
+	assert.Equal(t, []Type{ChargeStarted}, types(d.Vitals(now, &wallconnector.Vitals{UptimeS: 30, VehicleConnected: true, VehicleCurrentA: 32})))
+	assert.Empty(t, d.Vitals(now, &wallconnector.Vitals{UptimeS: 40, VehicleConnected: true, VehicleCurrentA: 31}))
+
+	events := d.Vitals(now, &wallconnector.Vitals{UptimeS: 50, VehicleConnected: true, CurrentAlerts: []int32{5}})
+	assert.Equal(t, []Type{ChargeStopped, AlertRaised}, types(events))
+	assert.Equal(t, int32(5), events[1].Alert)
+
+	assert.Equal(t, []Type{Rebooted, VehicleDisconnected, AlertCleared}, types(d.Vitals(now, &wallconnector.Vitals{UptimeS: 5})))
+
+	assert.Empty(t, d.Lifetime(now, &wallconnector.Lifetime{ThermalFoldbackCount: 3}))
+	assert.Empty(t, d.Lifetime(now, &wallconnector.Lifetime{ThermalFoldbackCount: 3}))
+	assert.Equal(t, []Type{ThermalFoldback}, types(d.Lifetime(now, &wallconnector.Lifetime{ThermalFoldbackCount: 4})))
+}
+
+func TestWebhooks(t *testing.T) {
+	bodies := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(r.Body)
+		bodies <- r.URL.Path + " " + string(data)
+	}))
+	defer srv.Close()
+
+	hooks := []*Webhook{
+		{URL: srv.URL + "/all"},
+		{URL: srv.URL + "/slack", Events: []Type{ChargeStopped}, Body: `{"text": {{ printf "Charge stopped at %.0fV" .Vitals.GridV | json }}}`},
+	}
+	config, _ := json.Marshal(hooks)
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	assert.NoError(t, os.WriteFile(path, config, 0o644))
+	hooks, err := LoadWebhooks(path)
+	assert.NoError(t, err)
+
+	n := &Notifier{Hooks: hooks}
+	n.Notify(context.Background(), Event{Type: VehicleConnected, Time: time.Unix(0, 0).UTC()})
+	n.Notify(context.Background(), Event{Type: ChargeStopped, Time: time.Unix(0, 0).UTC(), Vitals: &wallconnector.Vitals{GridV: 240}})
+
+	assert.Equal(t, `/all {"type":"vehicle_connected","time":"1970-01-01T00:00:00Z"}`+"\n", <-bodies)
+	assert.Contains(t, <-bodies, `/all {"type":"charge_stopped"`)
+	assert.Equal(t, `/slack {"text": "Charge stopped at 240V"}`, <-bodies)
+}
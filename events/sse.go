@@ -0,0 +1,118 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+// How often to send a comment to keep idle connections open.
+const keepAliveInterval = 30 * time.Second
+
+// A message sent to stream subscribers.
+type message struct {
+	event string
+	data  []byte
+}
+
+// A Stream fans out events, and optionally every vitals sample, to clients
+// as Server-Sent Events.
+//
+// Events are sent with the event type as the SSE event name and the [Event]
+// as JSON data. Clients which request vitals with ?vitals=true also receive
+// "vitals" events with a [wallconnector.VitalsSample] as data. Other packages
+// may publish their own events with [Stream.Publish]. Messages are dropped for
+// clients which fall behind.
+type Stream struct {
+	mu   sync.Mutex
+	subs map[chan message]bool
+}
+
+func NewStream() *Stream {
+	return &Stream{subs: make(map[chan message]bool)}
+}
+
+// PublishEvent sends e to all clients.
+func (s *Stream) PublishEvent(e Event) {
+	s.publish(string(e.Type), e, false)
+}
+
+// PublishVitals sends a vitals sample to clients which requested them.
+func (s *Stream) PublishVitals(v wallconnector.VitalsSample) {
+	s.publish("vitals", v, true)
+}
+
+// Publish sends v as JSON to all clients, with event as the SSE event name.
+func (s *Stream) Publish(event string, v any) {
+	s.publish(event, v, false)
+}
+
+func (s *Stream) publish(event string, v any, vitals bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("encoding %s event: %v", event, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch, wantVitals := range s.subs {
+		if vitals && !wantVitals {
+			continue
+		}
+		select {
+		case ch <- message{event: event, data: data}:
+		default:
+			// The client is falling behind.
+		}
+	}
+}
+
+func (s *Stream) subscribe(vitals bool) chan message {
+	ch := make(chan message, 16)
+	s.mu.Lock()
+	s.subs[ch] = vitals
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *Stream) unsubscribe(ch chan message) {
+	s.mu.Lock()
+	delete(s.subs, ch)
+	s.mu.Unlock()
+}
+
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := s.subscribe(r.URL.Query().Get("vitals") == "true")
+	defer s.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case msg := <-ch:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.event, msg.data)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+)
+
+// connect subscribes to the stream and returns a reader of its lines.
+func connect(t *testing.T, srv *httptest.Server, query string) *bufio.Reader {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+query, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+func readMessage(t *testing.T, r *bufio.Reader) string {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "\n" {
+			return strings.Join(lines, "")
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestStream(t *testing.T) {
+	s := NewStream()
+	srv := httptest.NewServer(s)
+	// Cleanups run last in first out, so the clients disconnect first.
+	t.Cleanup(srv.Close)
+
+	events := connect(t, srv, "/")
+	vitals := connect(t, srv, "/?vitals=true")
+	// Wait for both clients to subscribe.
+	for {
+		s.mu.Lock()
+		n := len(s.subs)
+		s.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	s.PublishVitals(wallconnector.VitalsSample{Time: time.Unix(0, 0).UTC(), Vitals: &wallconnector.Vitals{GridV: 240}})
+	s.PublishEvent(Event{Type: ChargeStarted, Time: time.Unix(0, 0).UTC()})
+
+	charge := "event: charge_started\ndata: {\"type\":\"charge_started\",\"time\":\"1970-01-01T00:00:00Z\"}\n"
+	assert.Equal(t, charge, readMessage(t, events))
+	assert.Equal(t, "event: vitals\ndata: {\"time\":\"1970-01-01T00:00:00Z\",\"vitals\":{\"grid_v\":240}}\n", readMessage(t, vitals))
+	assert.Equal(t, charge, readMessage(t, vitals))
+
+	s.Publish("solar", map[string]float64{"surplus_w": 1200})
+	solar := "event: solar\ndata: {\"surplus_w\":1200}\n"
+	assert.Equal(t, solar, readMessage(t, events))
+	assert.Equal(t, solar, readMessage(t, vitals))
+}
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"text/template"
+	"time"
+)
+
+// A Webhook is a URL which is POSTed to when an event happens.
+//
+// The body is rendered from a text/template with the [Event] as data, or is
+// the event as JSON if no template is given. The json function encodes a
+// value as JSON, e.g. for a Slack message:
+//
+//	{"text": {{ printf "Charger %s" .Type | json }}}
+type Webhook struct {
+	URL string `json:"url"`
+
+	// Events to notify about, or all events if empty.
+	Events []Type `json:"events,omitempty"`
+
+	Body    string            `json:"body,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	tmpl *template.Template
+}
+
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// LoadWebhooks reads a JSON array of webhooks from path.
+func LoadWebhooks(path string) ([]*Webhook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []*Webhook
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for _, hook := range hooks {
+		if err := hook.parse(); err != nil {
+			return nil, err
+		}
+	}
+	return hooks, nil
+}
+
+func (w *Webhook) parse() error {
+	if w.Body == "" || w.tmpl != nil {
+		return nil
+	}
+	tmpl, err := template.New(w.URL).Funcs(funcs).Parse(w.Body)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", w.URL, err)
+	}
+	w.tmpl = tmpl
+	return nil
+}
+
+// Send POSTs the event to the webhook.
+func (w *Webhook) Send(ctx context.Context, client *http.Client, e Event) error {
+	if err := w.parse(); err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	if w.tmpl != nil {
+		if err := w.tmpl.Execute(&body, e); err != nil {
+			return fmt.Errorf("webhook %s: %w", w.URL, err)
+		}
+	} else if err := json.NewEncoder(&body).Encode(e); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook %s: %s: %s", w.URL, resp.Status, msg)
+	}
+	return nil
+}
+
+// Matches reports whether the webhook should be sent for e.
+func (w *Webhook) Matches(e Event) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, e.Type)
+}
+
+// Timeout for sending a webhook with the default client of a [Notifier].
+const webhookTimeout = 10 * time.Second
+
+var defaultClient = &http.Client{Timeout: webhookTimeout}
+
+// A Notifier sends events to webhooks.
+type Notifier struct {
+	// Client to send webhooks with, or nil for a client which times out
+	// after 10 seconds, so a hung endpoint doesn't leak a goroutine per
+	// event.
+	Client *http.Client
+	Hooks  []*Webhook
+}
+
+// Notify sends e to all matching webhooks, logging any failures.
+func (n *Notifier) Notify(ctx context.Context, e Event) {
+	client := n.Client
+	if client == nil {
+		client = defaultClient
+	}
+	for _, hook := range n.Hooks {
+		if !hook.Matches(e) {
+			continue
+		}
+		if err := hook.Send(ctx, client, e); err != nil {
+			log.Printf("sending %s event: %v", e.Type, err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package wallconnector
+
+import (
+	"github.com/R167/wallconnector/internal/expr"
+	"google.golang.org/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// promField is a value of T exported as a metric: either an annotated field
+// or a derived metric.
+//
+// Tables of fields are generated by protoc-gen-wallconnector-prom (see
+// metrics.prom.go). Messages without generated code fall back to walking
+// their descriptors with reflection.
+type promField[T any] struct {
+	// Proto name of the field, or the expr of a derived metric.
+	name   string
+	metric *Metric
+	// Whether the raw value is an int32, which can overflow.
+	int32 bool
+	// The raw value. nil if the field has no numeric representation.
+	value func(T) float64
+}
+
+// promGenerated is implemented by messages with generated fields.
+type promGenerated[T any] interface {
+	promFields() []promField[T]
+}
+
+// messageFields returns the fields of T which are exported as metrics,
+// followed by its derived metrics.
+func messageFields[T proto.Message]() []promField[T] {
+	var v T
+	if g, ok := any(v).(promGenerated[T]); ok {
+		return g.promFields()
+	}
+	return reflectFields[T]()
+}
+
+// reflectFields builds the fields of T from the annotations on its descriptor.
+// Panics on invalid annotations.
+func reflectFields[T proto.Message]() []promField[T] {
+	var fields []promField[T]
+
+	var v T
+	desc := v.ProtoReflect().Descriptor()
+	for i := 0; i < desc.Fields().Len(); i++ {
+		field := desc.Fields().Get(i)
+		opts := field.Options().(*descriptorpb.FieldOptions)
+		ext, ok := proto.GetExtension(opts, E_Prometheus).(*Metric)
+		if !ok || ext.GetName() == "" || ext.GetSkip() {
+			continue
+		}
+
+		f := promField[T]{
+			name:   string(field.Name()),
+			metric: ext,
+			int32:  field.Kind() == protoreflect.Int32Kind,
+		}
+		if _, ok := fieldValue(field, field.Default()); ok {
+			f.value = func(x T) float64 {
+				val, _ := fieldValue(field, x.ProtoReflect().Get(field))
+				return val
+			}
+		}
+		fields = append(fields, f)
+	}
+
+	opts := desc.Options().(*descriptorpb.MessageOptions)
+	for _, ext := range proto.GetExtension(opts, E_Derived).([]*Metric) {
+		e, err := expr.Parse(ext.GetExpr())
+		if err != nil {
+			panic(err)
+		}
+		vars := make(map[string]protoreflect.FieldDescriptor)
+		for _, name := range expr.Vars(e) {
+			field := desc.Fields().ByName(protoreflect.Name(name))
+			if field == nil {
+				panic("unknown field " + name + " in expr " + ext.GetExpr())
+			}
+			if _, ok := fieldValue(field, field.Default()); !ok {
+				panic("unsupported field " + name + " in expr " + ext.GetExpr())
+			}
+			vars[name] = field
+		}
+
+		fields = append(fields, promField[T]{
+			name:   ext.GetExpr(),
+			metric: ext,
+			value: func(x T) float64 {
+				values := make(map[string]float64, len(vars))
+				for name, field := range vars {
+					values[name], _ = fieldValue(field, x.ProtoReflect().Get(field))
+				}
+				// All fields have been checked above, so this can't fail.
+				val, _ := e.Eval(values)
+				return val
+			},
+		})
+	}
+	return fields
+}
+
+// fieldValue converts a scalar field value to a float64. Returns false for
+// kinds which have no numeric representation.
+func fieldValue(field protoreflect.FieldDescriptor, value protoreflect.Value) (float64, bool) {
+	if field.Cardinality() == protoreflect.Repeated {
+		return 0, false
+	}
+	switch field.Kind() {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float(), true
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return float64(value.Int()), true
+	case protoreflect.BoolKind:
+		return promBool(value.Bool()), true
+	default:
+		return 0, false
+	}
+}
+
+// promBool converts a bool to 0 or 1.
+func promBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package wallconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// populate sets every numeric field of m to a distinct value.
+func populate(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Cardinality() == protoreflect.Repeated {
+			continue
+		}
+		switch field.Kind() {
+		case protoreflect.DoubleKind:
+			m.Set(field, protoreflect.ValueOfFloat64(float64(i)+0.5))
+		case protoreflect.Int32Kind:
+			m.Set(field, protoreflect.ValueOfInt32(int32(i)))
+		case protoreflect.Int64Kind:
+			m.Set(field, protoreflect.ValueOfInt64(int64(i)))
+		case protoreflect.BoolKind:
+			m.Set(field, protoreflect.ValueOfBool(i%2 == 0))
+		}
+	}
+}
+
+func assertGeneratedFields[T proto.Message](t *testing.T, v T) {
+	populate(v.ProtoReflect())
+
+	generated := any(v).(promGenerated[T]).promFields()
+	reflected := reflectFields[T]()
+	if !assert.Len(t, generated, len(reflected)) {
+		return
+	}
+	for i, want := range reflected {
+		got := generated[i]
+		assert.Equal(t, want.name, got.name)
+		assert.True(t, proto.Equal(want.metric, got.metric), want.name)
+		assert.Equal(t, want.int32, got.int32, want.name)
+		assert.Equal(t, want.value(v), got.value(v), want.name)
+	}
+}
+
+func TestGeneratedFields(t *testing.T) {
+	assertGeneratedFields(t, &Vitals{})
+	assertGeneratedFields(t, &Lifetime{})
+	assertGeneratedFields(t, &Wifi{})
+}
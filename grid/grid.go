@@ -0,0 +1,362 @@
+// Package grid monitors the quality of the power supplying a wall connector,
+// using it as a power quality meter.
+//
+// The [Analyzer] records sags, swells and interruptions of the grid and phase
+// voltages and excursions of the grid frequency, along with the imbalance
+// between phases and histograms of the voltages and frequency. Events are only
+// as precise as the polling interval, so short sags between polls are missed.
+package grid
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+// Nominal grid voltages and frequencies.
+var (
+	NominalVoltages    = []float64{120, 208, 230, 240, 277}
+	NominalFrequencies = []float64{50, 60}
+)
+
+// Nearest returns the value of nominal closest to v.
+func Nearest(v float64, nominal []float64) float64 {
+	best := nominal[0]
+	for _, n := range nominal[1:] {
+		if math.Abs(v-n) < math.Abs(v-best) {
+			best = n
+		}
+	}
+	return best
+}
+
+// Type is the type of a grid event.
+type Type string
+
+const (
+	Sag          Type = "sag"
+	Swell        Type = "swell"
+	Interruption Type = "interruption"
+	Frequency    Type = "frequency"
+)
+
+// Voltage below which a sag is an interruption, as a fraction of nominal, as
+// in IEEE 1159.
+const interruption = 0.1
+
+// An Event is a period the voltage or frequency of a channel was out of its
+// limits.
+type Event struct {
+	Type Type `json:"type"`
+	// The channel, e.g. grid or phase_a.
+	Channel string    `json:"channel"`
+	Start   time.Time `json:"start"`
+	// Zero while the event is ongoing.
+	End time.Time `json:"end,omitempty"`
+
+	Nominal float64 `json:"nominal"`
+	// The lowest value of a sag or interruption, highest of a swell, or
+	// furthest from nominal of a frequency excursion.
+	Extreme float64 `json:"extreme"`
+	// Deviation of the extreme from nominal, as a fraction of nominal.
+	Depth float64 `json:"depth"`
+}
+
+// Duration returns the duration of the event, or zero if it is ongoing.
+func (e Event) Duration() time.Duration {
+	if e.End.IsZero() {
+		return 0
+	}
+	return e.End.Sub(e.Start)
+}
+
+type Config func(*analyzerOpts)
+
+type analyzerOpts struct {
+	// Nominal voltage and frequency. Zero to use the nearest of
+	// NominalVoltages and NominalFrequencies to the median of the first
+	// samples.
+	Voltage   float64
+	Frequency float64
+
+	// Sag and swell thresholds as a fraction of nominal.
+	Sag, Swell float64
+
+	// Maximum deviation of the frequency from nominal, in hertz.
+	FrequencyBand float64
+}
+
+func WithNominal(volts, hertz float64) func(*analyzerOpts) {
+	return func(opts *analyzerOpts) {
+		opts.Voltage = volts
+		opts.Frequency = hertz
+	}
+}
+
+// WithLimits sets the sag and swell thresholds as fractions of nominal.
+// Defaults to 0.9 and 1.1, as in IEEE 1159.
+func WithLimits(sag, swell float64) func(*analyzerOpts) {
+	return func(opts *analyzerOpts) {
+		opts.Sag = sag
+		opts.Swell = swell
+	}
+}
+
+// WithFrequencyBand sets the maximum deviation of the frequency from nominal.
+// Defaults to 0.5 Hz.
+func WithFrequencyBand(hz float64) func(*analyzerOpts) {
+	return func(opts *analyzerOpts) {
+		opts.FrequencyBand = hz
+	}
+}
+
+// Number of events to keep.
+const maxEvents = 100
+
+// Number of samples the nominal values are inferred from, so a sag or swell
+// at startup isn't taken as nominal.
+const nominalSamples = 5
+
+// A channel is a voltage or frequency measured by the charger.
+type channel struct {
+	name  string
+	value func(*wallconnector.Vitals) float64
+	// Whether the channel is a frequency rather than a voltage.
+	frequency bool
+}
+
+var channels = []channel{
+	{name: "grid", value: (*wallconnector.Vitals).GetGridV},
+	{name: "phase_a", value: (*wallconnector.Vitals).GetVoltageAV},
+	{name: "phase_b", value: (*wallconnector.Vitals).GetVoltageBV},
+	{name: "phase_c", value: (*wallconnector.Vitals).GetVoltageCV},
+	{name: "grid", value: (*wallconnector.Vitals).GetGridHz, frequency: true},
+}
+
+// The state of a channel.
+type channelState struct {
+	// Zero until inferred from the pending samples.
+	nominal float64
+	pending []float64
+	// Nil until the nominal value is known.
+	histogram *histogram
+	// Index of the ongoing event in events, or -1.
+	event int
+}
+
+// An Analyzer analyzes the grid from successive polls of a single charger.
+// It is safe for concurrent use.
+type Analyzer struct {
+	opts *analyzerOpts
+
+	mu sync.Mutex
+	// Indexed by channel, nil until the channel is first measured.
+	states    []*channelState
+	events    []Event
+	imbalance float64
+	// Whether the imbalance has been measured.
+	balanced bool
+
+	metrics *metrics
+}
+
+func NewAnalyzer(opts ...Config) *Analyzer {
+	o := &analyzerOpts{
+		Sag:           0.9,
+		Swell:         1.1,
+		FrequencyBand: 0.5,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Analyzer{
+		opts:    o,
+		states:  make([]*channelState, len(channels)),
+		metrics: newMetrics(),
+	}
+}
+
+// Vitals adds a vitals sample. Samples with errors are ignored.
+func (a *Analyzer) Vitals(s wallconnector.VitalsSample) {
+	if s.Err != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var phases []float64
+	for i, ch := range channels {
+		v := ch.value(s.Vitals)
+		state := a.states[i]
+		if v <= 0 && (ch.frequency || state == nil || state.nominal == 0) {
+			// Not measured, e.g. the third phase of a split phase supply.
+			// A voltage which drops to zero once its nominal is known is an
+			// interruption.
+			continue
+		}
+		if v > 0 && !ch.frequency && ch.name != "grid" {
+			phases = append(phases, v)
+		}
+		a.sample(i, s.Time, v)
+	}
+	a.imbalance, a.balanced = imbalance(phases)
+}
+
+func (a *Analyzer) sample(i int, t time.Time, v float64) {
+	ch := channels[i]
+	state := a.states[i]
+	if state == nil {
+		state = &channelState{nominal: a.configured(ch), event: -1}
+		a.states[i] = state
+	}
+	state.pending = append(state.pending, v)
+	if state.nominal == 0 {
+		if len(state.pending) < nominalSamples {
+			return
+		}
+		state.nominal = inferNominal(ch, state.pending)
+	}
+	if state.histogram == nil {
+		state.histogram = newHistogram(state.nominal, ch.frequency)
+	}
+	for _, p := range state.pending {
+		state.histogram.observe(p)
+	}
+	state.pending = state.pending[:0]
+
+	typ, depth := a.classify(ch, state.nominal, v)
+	if state.event >= 0 {
+		e := &a.events[state.event]
+		if typ == e.Type {
+			// The event continues.
+			if depth > e.Depth {
+				e.Extreme, e.Depth = v, depth
+			}
+			return
+		}
+		a.endEvent(state, t)
+	}
+	if typ == "" {
+		return
+	}
+
+	a.events = append(a.events, Event{
+		Type:    typ,
+		Channel: ch.name,
+		Start:   t,
+		Nominal: state.nominal,
+		Extreme: v,
+		Depth:   depth,
+	})
+	state.event = len(a.events) - 1
+	a.metrics.events.WithLabelValues(ch.name, string(typ)).Inc()
+	a.trim()
+}
+
+func (a *Analyzer) endEvent(state *channelState, t time.Time) {
+	e := &a.events[state.event]
+	e.End = t
+	a.metrics.seconds.WithLabelValues(e.Channel, string(e.Type)).Add(e.Duration().Seconds())
+	state.event = -1
+}
+
+// trim drops the oldest events beyond maxEvents.
+func (a *Analyzer) trim() {
+	drop := len(a.events) - maxEvents
+	if drop <= 0 {
+		return
+	}
+	a.events = append(a.events[:0], a.events[drop:]...)
+	for _, state := range a.states {
+		if state != nil && state.event >= 0 {
+			state.event -= drop
+			// A long enough event may be dropped while it's ongoing, in
+			// which case it is forgotten.
+			if state.event < 0 {
+				state.event = -1
+			}
+		}
+	}
+}
+
+// configured returns the configured nominal value of a channel, or zero if
+// it should be inferred.
+func (a *Analyzer) configured(ch channel) float64 {
+	if ch.frequency {
+		return a.opts.Frequency
+	}
+	// Phase voltages are measured to neutral, so may be half of the grid
+	// voltage.
+	if ch.name == "grid" {
+		return a.opts.Voltage
+	}
+	return 0
+}
+
+// inferNominal returns the nominal value nearest to the median of samples.
+func inferNominal(ch channel, samples []float64) float64 {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+	if ch.frequency {
+		return Nearest(median, NominalFrequencies)
+	}
+	return Nearest(median, NominalVoltages)
+}
+
+// classify returns the type of event v is in, if any, and its deviation from
+// nominal as a fraction of nominal.
+func (a *Analyzer) classify(ch channel, nominal, v float64) (Type, float64) {
+	depth := math.Abs(v-nominal) / nominal
+	switch {
+	case ch.frequency:
+		if math.Abs(v-nominal) > a.opts.FrequencyBand {
+			return Frequency, depth
+		}
+	case v < nominal*interruption:
+		return Interruption, depth
+	case v < nominal*a.opts.Sag:
+		return Sag, depth
+	case v > nominal*a.opts.Swell:
+		return Swell, depth
+	}
+	return "", depth
+}
+
+// imbalance returns the voltage imbalance of phases, as the maximum deviation
+// from their mean over the mean (the NEMA definition). Requires at least two
+// phases.
+func imbalance(phases []float64) (float64, bool) {
+	if len(phases) < 2 {
+		return 0, false
+	}
+	var mean float64
+	for _, v := range phases {
+		mean += v
+	}
+	mean /= float64(len(phases))
+	var dev float64
+	for _, v := range phases {
+		dev = max(dev, math.Abs(v-mean))
+	}
+	return dev / mean, true
+}
+
+// Events returns the recorded events, oldest first, including those which
+// are ongoing.
+func (a *Analyzer) Events() []Event {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Event(nil), a.events...)
+}
+
+// Imbalance returns the latest imbalance between phase voltages, and whether
+// there were enough phases to measure it.
+func (a *Analyzer) Imbalance() (float64, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.imbalance, a.balanced
+}
@@ -0,0 +1,139 @@
+package grid
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func sample(t time.Time, gridV, phaseA, phaseB, hz float64) wallconnector.VitalsSample {
+	return wallconnector.VitalsSample{Time: t, Vitals: &wallconnector.Vitals{
+		GridV:     gridV,
+		VoltageAV: phaseA,
+		VoltageBV: phaseB,
+		GridHz:    hz,
+	}}
+}
+
+func TestAnalyzer(t *testing.T) {
+	a := NewAnalyzer()
+	start := time.Unix(0, 0).UTC()
+	for i := nominalSamples; i > 0; i-- {
+		a.Vitals(sample(start.Add(-time.Duration(i)*time.Second), 240, 120, 120, 60))
+	}
+	a.Vitals(sample(start, 240, 120, 120, 60))
+	a.Vitals(sample(start.Add(time.Second), 210, 105, 105, 60))
+	a.Vitals(sample(start.Add(2*time.Second), 200, 100, 110, 60.6))
+	a.Vitals(sample(start.Add(4*time.Second), 241, 120, 121, 60))
+
+	events := a.Events()
+	if assert.Len(t, events, 4) {
+		sag := events[0]
+		assert.Equal(t, Sag, sag.Type)
+		assert.Equal(t, "grid", sag.Channel)
+		assert.Equal(t, 3*time.Second, sag.Duration())
+		assert.Equal(t, 240.0, sag.Nominal)
+		assert.Equal(t, 200.0, sag.Extreme)
+		assert.InDelta(t, 40.0/240, sag.Depth, 1e-9)
+
+		assert.Equal(t, "phase_a", events[1].Channel)
+		assert.Equal(t, 100.0, events[1].Extreme)
+		assert.Equal(t, "phase_b", events[2].Channel)
+		// Recovered to 110V, above the 108V threshold, before the end.
+		assert.Equal(t, time.Second, events[2].Duration())
+
+		assert.Equal(t, Frequency, events[3].Type)
+		assert.Equal(t, 60.6, events[3].Extreme)
+		assert.Equal(t, 2*time.Second, events[3].Duration())
+	}
+
+	imbalance, ok := a.Imbalance()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5/120.5, imbalance, 1e-9)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(a.metrics.events.WithLabelValues("grid", "sag")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(a.metrics.seconds.WithLabelValues("grid", "sag")))
+	problems, err := testutil.CollectAndLint(a)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestAnalyzerSwellOngoing(t *testing.T) {
+	a := NewAnalyzer(WithNominal(230, 50), WithLimits(0.9, 1.05))
+	start := time.Unix(0, 0).UTC()
+	a.Vitals(sample(start, 245, 0, 0, 50))
+
+	events := a.Events()
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, Swell, events[0].Type)
+		assert.Equal(t, 230.0, events[0].Nominal)
+		assert.True(t, events[0].End.IsZero())
+	}
+	_, ok := a.Imbalance()
+	assert.False(t, ok)
+
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, httptest.NewRequest("GET", "/grid", nil))
+	assert.True(t, strings.HasPrefix(w.Body.String(), `{"events":[{"type":"swell","channel":"grid"`), w.Body.String())
+}
+
+func TestAnalyzerInfersNominal(t *testing.T) {
+	a := NewAnalyzer()
+	start := time.Unix(0, 0).UTC()
+	// Starts in a sag, which would be nearest to 208V.
+	for i, v := range []float64{205, 206, 240, 241, 239} {
+		a.Vitals(sample(start.Add(time.Duration(i)*time.Second), v, v/2, v/2, 60))
+		assert.Empty(t, a.Events())
+	}
+	a.Vitals(sample(start.Add(5*time.Second), 205, 102.5, 102.5, 60))
+
+	events := a.Events()
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, Sag, events[0].Type)
+		assert.Equal(t, 240.0, events[0].Nominal)
+		assert.Equal(t, 120.0, events[1].Nominal)
+	}
+	// The histograms include the samples the nominal values were inferred from.
+	assert.Equal(t, 4, testutil.CollectAndCount(a, "wallconnector_grid_quality_voltage_volts", "wallconnector_grid_quality_frequency_hertz"))
+	assert.Equal(t, uint64(6), a.states[0].histogram.count)
+	assert.Equal(t, uint64(6), a.states[1].histogram.count)
+}
+
+func TestAnalyzerInterruption(t *testing.T) {
+	a := NewAnalyzer(WithNominal(240, 60))
+	start := time.Unix(0, 0).UTC()
+	a.Vitals(sample(start, 240, 0, 0, 60))
+	// The supply drops out, then recovers through a sag.
+	a.Vitals(sample(start.Add(time.Second), 0, 0, 0, 0))
+	a.Vitals(sample(start.Add(3*time.Second), 200, 0, 0, 60))
+	a.Vitals(sample(start.Add(4*time.Second), 240, 0, 0, 60))
+
+	events := a.Events()
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, Interruption, events[0].Type)
+		assert.Equal(t, "grid", events[0].Channel)
+		assert.Equal(t, 0.0, events[0].Extreme)
+		assert.Equal(t, 1.0, events[0].Depth)
+		assert.Equal(t, 2*time.Second, events[0].Duration())
+		assert.Equal(t, Sag, events[1].Type)
+	}
+	// Phases which were never measured aren't interrupted.
+	assert.Nil(t, a.states[1])
+	assert.Equal(t, 1.0, testutil.ToFloat64(a.metrics.events.WithLabelValues("grid", "interruption")))
+}
+
+func TestHistogram(t *testing.T) {
+	h := newHistogram(240, false)
+	assert.Len(t, h.buckets, 31)
+	assert.InDelta(t, 204.0, h.buckets[0], 1e-9)
+	h.observe(240)
+	h.observe(300)
+	assert.Equal(t, uint64(1), h.counts[15])
+	assert.Equal(t, uint64(1), h.counts[30])
+	assert.Equal(t, uint64(2), h.count)
+}
@@ -0,0 +1,143 @@
+package grid
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// A histogram of a channel, with buckets around its nominal value.
+type histogram struct {
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// newHistogram returns a histogram with buckets every 1% of nominal between
+// 85% and 115% for voltages, or every 0.05 Hz within 0.5 Hz of nominal for
+// frequencies.
+func newHistogram(nominal float64, frequency bool) *histogram {
+	var buckets []float64
+	if frequency {
+		for i := -10; i <= 10; i++ {
+			buckets = append(buckets, math.Round((nominal+float64(i)*0.05)*100)/100)
+		}
+	} else {
+		for i := -15; i <= 15; i++ {
+			buckets = append(buckets, math.Round(nominal*(100+float64(i)))/100)
+		}
+	}
+	return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, upper := range h.buckets {
+		if v <= upper {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func (h *histogram) metric(desc *prometheus.Desc, labels ...string) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(h.buckets))
+	for i, upper := range h.buckets {
+		buckets[upper] = h.counts[i]
+	}
+	return prometheus.MustNewConstHistogram(desc, h.count, h.sum, buckets, labels...)
+}
+
+type metrics struct {
+	voltage   *prometheus.Desc
+	frequency *prometheus.Desc
+	imbalance *prometheus.Desc
+	events    *prometheus.CounterVec
+	seconds   *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		voltage: prometheus.NewDesc(
+			"wallconnector_grid_quality_voltage_volts",
+			"Distribution of the voltage of the grid or a phase.",
+			[]string{"channel"}, nil,
+		),
+		frequency: prometheus.NewDesc(
+			"wallconnector_grid_quality_frequency_hertz",
+			"Distribution of the frequency of the grid.",
+			nil, nil,
+		),
+		imbalance: prometheus.NewDesc(
+			"wallconnector_grid_quality_voltage_imbalance_ratio",
+			"Maximum deviation of a phase voltage from the mean of the phases, over the mean.",
+			nil, nil,
+		),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "wallconnector",
+			Subsystem: "grid_quality",
+			Name:      "events_total",
+			Help:      "Number of sags, swells, interruptions and frequency excursions.",
+		}, []string{"channel", "type"}),
+		seconds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "wallconnector",
+			Subsystem: "grid_quality",
+			Name:      "event_seconds_total",
+			Help:      "Duration of finished sags, swells, interruptions and frequency excursions.",
+		}, []string{"channel", "type"}),
+	}
+}
+
+func (a *Analyzer) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.metrics.voltage
+	ch <- a.metrics.frequency
+	ch <- a.metrics.imbalance
+	a.metrics.events.Describe(ch)
+	a.metrics.seconds.Describe(ch)
+}
+
+// Collect builds the metrics under the lock, and sends them after releasing
+// it so a slow scrape doesn't block polling.
+func (a *Analyzer) Collect(ch chan<- prometheus.Metric) {
+	var metrics []prometheus.Metric
+	a.mu.Lock()
+	for i, state := range a.states {
+		if state == nil || state.histogram == nil {
+			continue
+		}
+		if channels[i].frequency {
+			metrics = append(metrics, state.histogram.metric(a.metrics.frequency))
+		} else {
+			metrics = append(metrics, state.histogram.metric(a.metrics.voltage, channels[i].name))
+		}
+	}
+	if a.balanced {
+		metrics = append(metrics, prometheus.MustNewConstMetric(a.metrics.imbalance, prometheus.GaugeValue, a.imbalance))
+	}
+	a.mu.Unlock()
+	for _, m := range metrics {
+		ch <- m
+	}
+	a.metrics.events.Collect(ch)
+	a.metrics.seconds.Collect(ch)
+}
+
+// ServeHTTP serves the recorded events as JSON.
+func (a *Analyzer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	type event struct {
+		Event
+		Seconds float64 `json:"duration_seconds,omitempty"`
+	}
+	events := []event{}
+	for _, e := range a.Events() {
+		events = append(events, event{Event: e, Seconds: e.Duration().Seconds()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"events": events})
+}
+
+// Ensure we implement the [prometheus.Collector] interface
+var _ prometheus.Collector = (*Analyzer)(nil)
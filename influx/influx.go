@@ -0,0 +1,141 @@
+// Package influx renders wallconnector samples as InfluxDB line protocol.
+//
+// Each metric set is a measurement (e.g. wallconnector_vitals), the labels of
+// the annotations are tags and the values are fields named after the metric.
+// Samples with the same labels are written as a single line:
+//
+//	wallconnector_vitals,phase=A wall_amperes=16,wall_volts=240,wall_watts=3840 1700000000000000000
+package influx
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+// Timeout for writes with the default client, so a hung server doesn't stall
+// every later write.
+const writeTimeout = 10 * time.Second
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// Encode writes samples observed at t as line protocol. tags are added to
+// every line, e.g. to identify the charger.
+func Encode(w io.Writer, samples []wallconnector.Sample, tags map[string]string, t time.Time) error {
+	type line struct {
+		key    string
+		fields []string
+	}
+	var lines []*line
+	byKey := make(map[string]*line)
+	for _, s := range samples {
+		// NaN can't be represented in line protocol.
+		if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+			continue
+		}
+
+		key := measurementEscaper.Replace("wallconnector_"+s.Set) + encodeTags(s.Labels, tags)
+		l, ok := byKey[key]
+		if !ok {
+			l = &line{key: key}
+			byKey[key] = l
+			lines = append(lines, l)
+		}
+		l.fields = append(l.fields, keyEscaper.Replace(s.Metric.GetName())+"="+strconv.FormatFloat(s.Value, 'g', -1, 64))
+	}
+
+	bw := bufio.NewWriter(w)
+	ts := strconv.FormatInt(t.UnixNano(), 10)
+	for _, l := range lines {
+		fmt.Fprintf(bw, "%s %s %s\n", l.key, strings.Join(l.fields, ","), ts)
+	}
+	return bw.Flush()
+}
+
+// encodeTags returns the sorted tag set, starting with a comma.
+func encodeTags(sets ...map[string]string) string {
+	var tags []string
+	for _, set := range sets {
+		for k, v := range set {
+			tags = append(tags, keyEscaper.Replace(k)+"="+keyEscaper.Replace(v))
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	sort.Strings(tags)
+	return "," + strings.Join(tags, ",")
+}
+
+// A Writer writes samples to the InfluxDB v2 write API.
+type Writer struct {
+	// http.Client to use for requests, defaults to a client timing out after
+	// 10 seconds.
+	Client *http.Client
+
+	url   string
+	token string
+}
+
+// NewWriter creates a writer for the bucket in org on the InfluxDB server at
+// addr, e.g. http://localhost:8086.
+func NewWriter(addr, org, bucket, token string) (*Writer, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	u = u.JoinPath("/api/v2/write")
+	u.RawQuery = url.Values{
+		"org":       {org},
+		"bucket":    {bucket},
+		"precision": {"ns"},
+	}.Encode()
+
+	return &Writer{
+		Client: &http.Client{Timeout: writeTimeout},
+		url:    u.String(),
+		token:  token,
+	}, nil
+}
+
+// Write writes samples observed at t.
+func (w *Writer) Write(ctx context.Context, samples []wallconnector.Sample, tags map[string]string, t time.Time) error {
+	var body bytes.Buffer
+	if err := Encode(&body, samples, tags, t); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if w.token != "" {
+		req.Header.Set("Authorization", "Token "+w.token)
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("write failed: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
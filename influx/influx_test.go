@@ -0,0 +1,52 @@
+package influx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	samples := []wallconnector.Sample{
+		{Set: "vitals", Metric: &wallconnector.Metric{Name: "grid_voltage"}, Value: 240},
+		{Set: "vitals", Metric: &wallconnector.Metric{Name: "wall_amperes"}, Labels: map[string]string{"phase": "A"}, Value: 16},
+		{Set: "vitals", Metric: &wallconnector.Metric{Name: "wall_volts"}, Labels: map[string]string{"phase": "A"}, Value: 120.5},
+		{Set: "vitals", Metric: &wallconnector.Metric{Name: "uptime_seconds_total"}, Value: 1e7},
+		{Set: "wifi", Metric: &wallconnector.Metric{Name: "rssi"}, Value: -53},
+	}
+
+	var b strings.Builder
+	err := Encode(&b, samples, map[string]string{"charger": "garage door"}, time.Unix(1, 5))
+	assert.NoError(t, err)
+	assert.Equal(t, `wallconnector_vitals,charger=garage\ door grid_voltage=240,uptime_seconds_total=1e+07 1000000005
+wallconnector_vitals,charger=garage\ door,phase=A wall_amperes=16,wall_volts=120.5 1000000005
+wallconnector_wifi,charger=garage\ door rssi=-53 1000000005
+`, b.String())
+}
+
+func TestWriter(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/write", r.URL.Path)
+		assert.Equal(t, "home", r.URL.Query().Get("org"))
+		assert.Equal(t, "energy", r.URL.Query().Get("bucket"))
+		assert.Equal(t, "Token secret", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w, err := NewWriter(srv.URL, "home", "energy", "secret")
+	assert.NoError(t, err)
+	samples := wallconnector.MessageSamples("wifi", &wallconnector.Wifi{WifiRssi: -53})
+	assert.NoError(t, w.Write(context.Background(), samples, nil, time.Unix(1, 0)))
+	assert.Contains(t, body, "wallconnector_wifi signal_strength_ratio=0,rssi=-53,snr=0 1000000000\n")
+}
@@ -0,0 +1,189 @@
+// Package annotation validates the (prometheus) and (derived) annotations in
+// metrics.proto, so that mistakes are caught when generating code rather than
+// as panics at scrape time.
+package annotation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/internal/expr"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	metricNameRE = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRE  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// An Issue is a problem with the annotation on a field or message.
+type Issue struct {
+	Element protoreflect.FullName
+	Message string
+}
+
+func (i Issue) Error() string {
+	return string(i.Element) + ": " + i.Message
+}
+
+// FieldMetric returns the (prometheus) annotation of a field, or nil.
+func FieldMetric(field protoreflect.FieldDescriptor) *wallconnector.Metric {
+	metric, _ := proto.GetExtension(field.Options(), wallconnector.E_Prometheus).(*wallconnector.Metric)
+	return metric
+}
+
+// DerivedMetrics returns the (derived) annotations of a message.
+func DerivedMetrics(msg protoreflect.MessageDescriptor) []*wallconnector.Metric {
+	metrics, _ := proto.GetExtension(msg.Options(), wallconnector.E_Derived).([]*wallconnector.Metric)
+	return metrics
+}
+
+// Annotated reports whether any field of msg has a metric or msg declares
+// derived metrics.
+func Annotated(msg protoreflect.MessageDescriptor) bool {
+	for i := 0; i < msg.Fields().Len(); i++ {
+		if FieldMetric(msg.Fields().Get(i)) != nil {
+			return true
+		}
+	}
+	return len(DerivedMetrics(msg)) > 0
+}
+
+// Numeric reports whether field has a numeric representation in an exported
+// metric.
+func Numeric(field protoreflect.FieldDescriptor) bool {
+	if field.Cardinality() == protoreflect.Repeated {
+		return false
+	}
+	switch field.Kind() {
+	case protoreflect.FloatKind, protoreflect.DoubleKind,
+		protoreflect.Int32Kind, protoreflect.Int64Kind,
+		protoreflect.BoolKind:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check validates the annotations of msg, returning an issue for each
+// annotation which would fail when building or collecting its metrics.
+func Check(msg protoreflect.MessageDescriptor) []Issue {
+	c := &checker{shared: make(map[string]shared)}
+	for i := 0; i < msg.Fields().Len(); i++ {
+		field := msg.Fields().Get(i)
+		metric := FieldMetric(field)
+		if metric == nil {
+			continue
+		}
+		if metric.GetName() == "" {
+			// Fields without a name are ignored.
+			continue
+		}
+		if metric.GetSkip() {
+			continue
+		}
+		if !Numeric(field) {
+			c.errorf(field.FullName(), "unsupported field type %s", field.Kind())
+		}
+		if metric.GetExpr() != "" {
+			c.errorf(field.FullName(), "expr is only supported on derived metrics")
+		}
+		c.metric(field.FullName(), metric)
+	}
+	for _, metric := range DerivedMetrics(msg) {
+		el := msg.FullName().Append(protoreflect.Name(metric.GetName()))
+		c.metric(el, metric)
+		if metric.GetDeadband() != 0 {
+			c.errorf(el, "deadband is only supported on fields")
+		}
+
+		e, err := expr.Parse(metric.GetExpr())
+		if err != nil {
+			c.errorf(el, "%v", err)
+			continue
+		}
+		for _, name := range expr.Vars(e) {
+			field := msg.Fields().ByName(protoreflect.Name(name))
+			if field == nil {
+				c.errorf(el, "unknown field %q in expr", name)
+			} else if !Numeric(field) {
+				c.errorf(el, "field %q in expr is not numeric", name)
+			}
+		}
+	}
+	return c.issues
+}
+
+// Metrics sharing a name must agree on their type and label keys.
+type shared struct {
+	el   protoreflect.FullName
+	typ  wallconnector.Metric_Type
+	keys string
+}
+
+type checker struct {
+	issues []Issue
+	shared map[string]shared
+}
+
+func (c *checker) errorf(el protoreflect.FullName, format string, args ...any) {
+	c.issues = append(c.issues, Issue{Element: el, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) metric(el protoreflect.FullName, metric *wallconnector.Metric) {
+	name := metric.GetName()
+	if !metricNameRE.MatchString(name) {
+		c.errorf(el, "invalid metric name %q", name)
+	}
+	if _, ok := wallconnector.Metric_Type_name[int32(metric.GetType())]; !ok {
+		c.errorf(el, "unknown metric type %d", metric.GetType())
+	}
+	if _, ok := wallconnector.Conversion_name[int32(metric.GetConversion())]; !ok {
+		c.errorf(el, "unknown conversion %d", metric.GetConversion())
+	}
+	if metric.GetMonotonic() && metric.GetType() != wallconnector.Metric_COUNTER {
+		c.errorf(el, "monotonic is only supported on counters")
+	}
+	for _, agg := range metric.GetAggregate() {
+		if _, ok := wallconnector.Metric_Aggregate_name[int32(agg)]; !ok {
+			c.errorf(el, "unknown aggregate %d", agg)
+		} else if agg != wallconnector.Metric_NONE && metric.GetType() != wallconnector.Metric_GAUGE {
+			c.errorf(el, "aggregate %s is only supported on gauges", agg)
+		}
+	}
+	if metric.GetDeadband() < 0 {
+		c.errorf(el, "negative deadband %v", metric.GetDeadband())
+	}
+	if r := metric.GetValid(); r != nil && r.Min != nil && r.Max != nil && r.GetMin() > r.GetMax() {
+		c.errorf(el, "valid range min %v is greater than max %v", r.GetMin(), r.GetMax())
+	}
+
+	keys := make([]string, 0, len(metric.GetLabels()))
+	for _, label := range metric.GetLabels() {
+		key, _, ok := strings.Cut(label, ":")
+		if !ok {
+			c.errorf(el, "invalid label %q, expected key:value", label)
+			continue
+		}
+		if !labelNameRE.MatchString(key) || strings.HasPrefix(key, "__") {
+			c.errorf(el, "invalid label name %q", key)
+		}
+		keys = append(keys, key)
+	}
+
+	s := shared{el: el, typ: metric.GetType(), keys: strings.Join(keys, ",")}
+	prev, ok := c.shared[name]
+	if !ok {
+		c.shared[name] = s
+		return
+	}
+	if prev.typ != s.typ {
+		c.errorf(el, "metric %q has type %s, but %s has type %s", name, s.typ, prev.el, prev.typ)
+	}
+	if prev.keys != s.keys {
+		c.errorf(el, "metric %q has labels [%s], but %s has labels [%s]", name, s.keys, prev.el, prev.keys)
+	}
+}
@@ -0,0 +1,131 @@
+package annotation
+
+import (
+	"testing"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestCheckMetricsProto(t *testing.T) {
+	for _, msg := range []protoreflect.ProtoMessage{
+		&wallconnector.Vitals{},
+		&wallconnector.Lifetime{},
+		&wallconnector.Wifi{},
+	} {
+		assert.Empty(t, Check(msg.ProtoReflect().Descriptor()))
+	}
+}
+
+// field returns a double field annotated with metric.
+func field(name string, metric *wallconnector.Metric) *descriptorpb.FieldDescriptorProto {
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, wallconnector.E_Prometheus, metric)
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(int32(len(name))),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Options:  opts,
+	}
+}
+
+// message builds a message descriptor with the given fields and derived metrics.
+func message(t *testing.T, fields []*descriptorpb.FieldDescriptorProto, derived ...*wallconnector.Metric) protoreflect.MessageDescriptor {
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, wallconnector.E_Derived, derived)
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:    proto.String("Test"),
+			Field:   fields,
+			Options: opts,
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd.Messages().Get(0)
+}
+
+func TestCheck(t *testing.T) {
+	msg := message(t, []*descriptorpb.FieldDescriptorProto{
+		field("a", &wallconnector.Metric{Name: "bad-name"}),
+		field("bb", &wallconnector.Metric{Name: "amps", Labels: []string{"phase"}}),
+		field("ccc", &wallconnector.Metric{Name: "amps", Type: wallconnector.Metric_COUNTER, Labels: []string{"phase:A"}}),
+		field("dddd", &wallconnector.Metric{Name: "volts", Labels: []string{"__phase:A"}, Monotonic: true}),
+		field("eeeee", &wallconnector.Metric{Name: "temp", Deadband: -1}),
+		field("ffffff", &wallconnector.Metric{Name: "starts", Type: wallconnector.Metric_COUNTER, Aggregate: []wallconnector.Metric_Aggregate{wallconnector.Metric_MINMAX}}),
+	},
+		&wallconnector.Metric{Name: "watts", Expr: "volts * a", Deadband: 1},
+		&wallconnector.Metric{Name: "watts2", Expr: "a *"},
+	)
+
+	var messages []string
+	for _, issue := range Check(msg) {
+		messages = append(messages, issue.Error())
+	}
+	assert.Equal(t, []string{
+		`test.Test.a: invalid metric name "bad-name"`,
+		`test.Test.bb: invalid label "phase", expected key:value`,
+		`test.Test.ccc: metric "amps" has type COUNTER, but test.Test.bb has type GAUGE`,
+		`test.Test.ccc: metric "amps" has labels [phase], but test.Test.bb has labels []`,
+		`test.Test.dddd: monotonic is only supported on counters`,
+		`test.Test.dddd: invalid label name "__phase"`,
+		`test.Test.eeeee: negative deadband -1`,
+		`test.Test.ffffff: aggregate MINMAX is only supported on gauges`,
+		`test.Test.watts: deadband is only supported on fields`,
+		`test.Test.watts: unknown field "volts" in expr`,
+		`test.Test.watts2: expr "a *" at 3: unexpected end of expression`,
+	}, messages)
+}
+
+func TestLint(t *testing.T) {
+	msg := message(t, []*descriptorpb.FieldDescriptorProto{
+		field("a", &wallconnector.Metric{Name: "uptime_seconds"}),
+		field("bb", &wallconnector.Metric{Name: "uptime_total"}),
+		field("ccc", &wallconnector.Metric{Name: "thermopile_uv"}),
+		field("dddd", &wallconnector.Metric{Name: "amperes", Help: "A", Labels: []string{"phase:A"}}),
+		field("eeeee", &wallconnector.Metric{Name: "amperes", Help: "B", Labels: []string{"phase:B"}}),
+		field("ffffff", &wallconnector.Metric{Skip: true}),
+		{
+			Name:     proto.String("unannotated"),
+			JsonName: proto.String("unannotated"),
+			Number:   proto.Int32(100),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum(),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		},
+	},
+		&wallconnector.Metric{Name: "energy_kwh_total", Type: wallconnector.Metric_COUNTER, Expr: "a * bb"},
+	)
+
+	var messages []string
+	for _, issue := range Lint(msg) {
+		messages = append(messages, issue.Error())
+	}
+	assert.Equal(t, []string{
+		`test.Test.bb: metric "uptime_total" ends with _total but is a GAUGE`,
+		`test.Test.ccc: metric "thermopile_uv" should use base unit volts, see Conversion`,
+		`test.Test.eeeee: metric "amperes" has different help than test.Test.dddd`,
+		`test.Test.ffffff: skipped fields still require a name`,
+		`test.Test.energy_kwh_total: metric "energy_kwh_total" should use base unit joules, see Conversion`,
+	}, messages)
+}
+
+func TestLintMetricsProto(t *testing.T) {
+	for _, msg := range []protoreflect.ProtoMessage{
+		&wallconnector.Vitals{},
+		&wallconnector.Lifetime{},
+		&wallconnector.Version{},
+		&wallconnector.Wifi{},
+	} {
+		assert.Empty(t, Lint(msg.ProtoReflect().Descriptor()))
+	}
+}
@@ -0,0 +1,89 @@
+package annotation
+
+import (
+	"strings"
+
+	"github.com/R167/wallconnector"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Units which have a prometheus base unit that should be used instead, by
+// the name suffix they usually appear as.
+var nonBaseUnits = map[string]string{
+	"ms":           "seconds",
+	"us":           "seconds",
+	"milliseconds": "seconds",
+	"microseconds": "seconds",
+	"minutes":      "seconds",
+	"hours":        "seconds",
+	"mv":           "volts",
+	"uv":           "volts",
+	"millivolts":   "volts",
+	"microvolts":   "volts",
+	"ma":           "amperes",
+	"milliamperes": "amperes",
+	"wh":           "joules",
+	"kwh":          "joules",
+	"percent":      "ratio",
+	"fahrenheit":   "celsius",
+	"kelvin":       "celsius",
+	"hz":           "seconds",
+}
+
+// Lint checks the annotations of msg for everything [Check] does, as well as
+// for prometheus naming conventions and consistency which won't fail at
+// runtime, but produce confusing metrics:
+//
+//   - only counters end with _total, and all counters do
+//   - names use base units (seconds, volts, joules, ...)
+//   - metrics sharing a name have identical help
+//   - annotations have a name, even when skipped
+func Lint(msg protoreflect.MessageDescriptor) []Issue {
+	c := &checker{issues: Check(msg)}
+	type described struct {
+		el   protoreflect.FullName
+		help string
+	}
+	help := make(map[string]described)
+	lint := func(el protoreflect.FullName, metric *wallconnector.Metric) {
+		name := metric.GetName()
+		if name == "" {
+			if metric.GetSkip() {
+				c.errorf(el, "skipped fields still require a name")
+			} else {
+				c.errorf(el, "annotation has no name and will be ignored")
+			}
+			return
+		}
+
+		total := strings.HasSuffix(name, "_total")
+		counter := metric.GetType() == wallconnector.Metric_COUNTER
+		if total && !counter {
+			c.errorf(el, "metric %q ends with _total but is a %s", name, metric.GetType())
+		} else if counter && !total {
+			c.errorf(el, "counter %q should end with _total", name)
+		}
+
+		parts := strings.Split(strings.TrimSuffix(name, "_total"), "_")
+		if base, ok := nonBaseUnits[parts[len(parts)-1]]; ok {
+			c.errorf(el, "metric %q should use base unit %s, see Conversion", name, base)
+		}
+
+		if prev, ok := help[name]; !ok {
+			help[name] = described{el: el, help: metric.GetHelp()}
+		} else if prev.help != metric.GetHelp() {
+			c.errorf(el, "metric %q has different help than %s", name, prev.el)
+		}
+	}
+
+	for i := 0; i < msg.Fields().Len(); i++ {
+		field := msg.Fields().Get(i)
+		if metric := FieldMetric(field); metric != nil {
+			lint(field.FullName(), metric)
+		}
+	}
+	for _, metric := range DerivedMetrics(msg) {
+		lint(msg.FullName().Append(protoreflect.Name(metric.GetName())), metric)
+	}
+	return c.issues
+}
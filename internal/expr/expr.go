@@ -0,0 +1,209 @@
+// Package expr implements the expressions used to declare derived metrics in
+// the metrics.proto annotations.
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// An Expr computes a value from the fields of a message.
+type Expr interface {
+	// Eval evaluates the expression with fields looked up by name in vars.
+	Eval(vars map[string]float64) (float64, error)
+}
+
+type (
+	// A numeric literal.
+	Num float64
+	// A reference to a field by its proto name.
+	Var string
+	// Negation of X.
+	Neg struct{ X Expr }
+	// A binary operation, one of + - * /.
+	Op struct {
+		Op   byte
+		L, R Expr
+	}
+)
+
+func (e Num) Eval(map[string]float64) (float64, error) {
+	return float64(e), nil
+}
+
+func (e Var) Eval(vars map[string]float64) (float64, error) {
+	v, ok := vars[string(e)]
+	if !ok {
+		return 0, fmt.Errorf("unknown field %q", string(e))
+	}
+	return v, nil
+}
+
+func (e Neg) Eval(vars map[string]float64) (float64, error) {
+	v, err := e.X.Eval(vars)
+	return -v, err
+}
+
+func (e Op) Eval(vars map[string]float64) (float64, error) {
+	l, err := e.L.Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	r, err := e.R.Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch e.Op {
+	case '+':
+		return l + r, nil
+	case '-':
+		return l - r, nil
+	case '*':
+		return l * r, nil
+	case '/':
+		return l / r, nil
+	default:
+		return 0, fmt.Errorf("unknown operator %q", e.Op)
+	}
+}
+
+// Vars returns the names of all fields referenced by e.
+func Vars(e Expr) []string {
+	switch e := e.(type) {
+	case Var:
+		return []string{string(e)}
+	case Neg:
+		return Vars(e.X)
+	case Op:
+		return append(Vars(e.L), Vars(e.R)...)
+	default:
+		return nil
+	}
+}
+
+// Parse parses a derived metric expression.
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ("*" | "/") factor }
+//	factor = number | field | "-" factor | "(" expr ")"
+func Parse(s string) (Expr, error) {
+	p := &parser{src: s}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return e, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("expr %q at %d: %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space byte, or 0 at the end of the input.
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) expr() (Expr, error) {
+	l, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		r, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		l = Op{Op: op, L: l, R: r}
+	}
+	return l, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	l, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		r, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		l = Op{Op: op, L: l, R: r}
+	}
+	return l, nil
+}
+
+func (p *parser) factor() (Expr, error) {
+	switch c := p.peek(); {
+	case c == 0:
+		return nil, p.errorf("unexpected end of expression")
+	case c == '-':
+		p.pos++
+		x, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return Neg{x}, nil
+	case c == '(':
+		p.pos++
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		return e, nil
+	case c == '.' || isDigit(c):
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.src[start:p.pos])
+		}
+		return Num(v), nil
+	case c == '_' || unicode.IsLetter(rune(c)):
+		start := p.pos
+		end := strings.IndexFunc(p.src[start:], func(r rune) bool {
+			return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if end < 0 {
+			end = len(p.src) - start
+		}
+		p.pos = start + end
+		return Var(p.src[start:p.pos]), nil
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
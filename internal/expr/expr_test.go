@@ -0,0 +1,50 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	vars := map[string]float64{
+		"voltageA_v": 240,
+		"currentA_a": 16,
+		"grid_hz":    60,
+	}
+
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"voltageA_v * currentA_a", 3840},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"-grid_hz / 2", -30},
+		{"voltageA_v*currentA_a/1000", 3.84},
+		{".5 * grid_hz", 30},
+	}
+	for _, tt := range tests {
+		e, err := Parse(tt.expr)
+		if !assert.NoError(t, err, tt.expr) {
+			continue
+		}
+		got, err := e.Eval(vars)
+		assert.NoError(t, err, tt.expr)
+		assert.InDelta(t, tt.want, got, 1e-9, tt.expr)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "1 +", "(1 + 2", "1 2", "a % b", "1..2"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+
+	e, err := Parse("missing * 2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, Vars(e))
+	_, err = e.Eval(nil)
+	assert.Error(t, err)
+}
@@ -2,15 +2,15 @@ package wallconnector
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/protobuf/proto"
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 // A prometheus.Collector implementation for wallconnector stats.
@@ -18,12 +18,21 @@ type collector struct {
 	timeout    time.Duration
 	client     *Client
 	metricSets []metricFetcher
+	counters   *counterGuard
+
+	// Expected serial number of the wallconnector, if pinned.
+	serial   string
+	mismatch *prometheus.Desc
 }
 
 func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	for _, set := range c.metricSets {
 		set.Describe(ch)
 	}
+	c.counters.Describe(ch)
+	if c.serial != "" {
+		ch <- c.mismatch
+	}
 }
 
 func (c *collector) Collect(ch chan<- prometheus.Metric) {
@@ -33,6 +42,14 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 		ctx, cancel = context.WithTimeout(ctx, c.timeout)
 		defer cancel()
 	}
+	if c.serial != "" {
+		if !c.verify(ctx, ch) {
+			return
+		}
+		c.counters.device(c.serial)
+	} else {
+		c.identify(ctx)
+	}
 	wait := sync.WaitGroup{}
 	for _, set := range c.metricSets {
 		wait.Add(1)
@@ -43,28 +60,107 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 		}()
 	}
 	wait.Wait()
+	c.counters.Collect(ch)
+	if err := c.counters.save(); err != nil {
+		log.Printf("saving counter state: %v", err)
+	}
+}
+
+// verify checks the wallconnector has the expected serial number, reporting
+// whether it does. Metrics from other devices must not be collected, so a
+// mismatch or failure to check is reported as an error.
+func (c *collector) verify(ctx context.Context, ch chan<- prometheus.Metric) bool {
+	version, err := c.client.Version(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.mismatch, fmt.Errorf("verifying serial number: %w", err))
+		return false
+	}
+	if got := version.GetSerialNumber(); got != c.serial {
+		ch <- prometheus.MustNewConstMetric(c.mismatch, prometheus.GaugeValue, 1)
+		ch <- prometheus.NewInvalidMetric(c.mismatch, fmt.Errorf("serial number %q at %s does not match expected %q", got, c.client.Addr(), c.serial))
+		return false
+	}
+	ch <- prometheus.MustNewConstMetric(c.mismatch, prometheus.GaugeValue, 0)
+	return true
+}
+
+// identify ties the counter state to the serial number of the device, so
+// pointing the exporter at another device doesn't take its counters as a
+// reset. If the version can't be read, the state is kept.
+func (c *collector) identify(ctx context.Context) {
+	version, err := c.client.Version(ctx)
+	if err != nil {
+		log.Printf("reading serial number for counter state: %v", err)
+		return
+	}
+	c.counters.device(version.GetSerialNumber())
 }
 
 // NewCollector creates a new collector for wallconnector stats.
-func NewCollector(client *Client) prometheus.Collector {
+func NewCollector(client *Client, opts ...CollectorConfig) prometheus.Collector {
+	o := &collectorOpts{AggregateWindow: time.Minute}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// Labels added to every metric.
+	var labels prometheus.Labels
+	if o.SerialNumber != "" {
+		labels = prometheus.Labels{"serial": o.SerialNumber}
+	}
+
+	counters := newCounterGuard(o.CounterState, labels)
+	vitals := newMetricSet("vitals", client.Vitals, counters, labels, o.AggregateWindow).(*metricSet[*Vitals])
+	if o.VitalsSamples != nil {
+		go func() {
+			for {
+				select {
+				case <-o.SamplesContext.Done():
+					return
+				case s, ok := <-o.VitalsSamples:
+					if !ok {
+						return
+					}
+					if s.Err == nil {
+						vitals.observe(s.Time, s.Vitals)
+					}
+				}
+			}
+		}()
+	}
 	return &collector{
-		client: client,
+		client:   client,
+		counters: counters,
 		metricSets: []metricFetcher{
-			newMetricSet("vitals", client.Vitals),
-			newMetricSet("lifetime", client.Lifetime),
-			newMetricSet("wifi", client.Wifi),
+			vitals,
+			newMetricSet("lifetime", client.Lifetime, counters, labels, o.AggregateWindow),
+			newMetricSet("wifi", client.Wifi, counters, labels, o.AggregateWindow),
 		},
+		serial: o.SerialNumber,
+		mismatch: prometheus.NewDesc(
+			"wallconnector_serial_mismatch",
+			"Whether the device at the target has a different serial number than expected.",
+			nil, labels,
+		),
 	}
 }
 
 // Ensure we implement the [prometheus.Collector] interface
 var _ prometheus.Collector = (*collector)(nil)
 
-type metricData struct {
+type metricData[T any] struct {
 	typ    prometheus.ValueType
 	labels []string
 	desc   *prometheus.Desc
 	metric *Metric
+	field  promField[T]
+
+	// Identifies the series for monotonic counters.
+	key string
+
+	// Aggregates of sampled values, nil unless annotated.
+	histogram *prometheus.HistogramVec
+	minmax    *minMax
 }
 
 type metricFetcher interface {
@@ -72,127 +168,174 @@ type metricFetcher interface {
 	Collect(ctx context.Context, ch chan<- prometheus.Metric)
 }
 
-// Mapping of metric JSONName to metric data for a particular endpoint.
+// Metrics for a particular endpoint.
 type metricSet[T proto.Message] struct {
-	metrics  map[string]metricData
+	metrics  []metricData[T]
 	fetcher  func(context.Context) (T, error)
 	overview prometheus.Summary
+	invalid  *prometheus.CounterVec
+	counters *counterGuard
+
+	aggregates *aggregates
+	now        func() time.Time
 }
 
 func (m *metricSet[T]) Describe(ch chan<- *prometheus.Desc) {
 	for _, metric := range m.metrics {
-		if metric.metric.GetSkip() {
-			continue
-		}
 		ch <- metric.desc
 	}
+	m.aggregates.Describe(ch)
 	m.overview.Describe(ch)
+	m.invalid.Describe(ch)
 }
 
 func (m *metricSet[T]) Collect(ctx context.Context, ch chan<- prometheus.Metric) {
 	start := time.Now()
 	logger := log.Default()
+	// Aggregates come from samples observed between scrapes, so don't
+	// depend on the fetch.
+	m.aggregates.Collect(ch)
+	for _, metric := range m.metrics {
+		if metric.minmax != nil {
+			metric.minmax.collect(ch, m.now())
+		}
+	}
 	v, err := m.fetcher(ctx)
 	if err != nil {
 		return
 	}
-	fields := v.ProtoReflect().Descriptor().Fields()
-	for i := 0; i < fields.Len(); i++ {
-		field := fields.Get(i)
-		metric, ok := m.metrics[field.JSONName()]
-		if !ok {
-			// Ignore unsupported types.
-			logger.Printf("unknown key %s(%s)", field.JSONName(), field.Kind())
-			continue
-		}
-		if metric.metric.GetSkip() {
-			continue
-		}
-
-		value := v.ProtoReflect().Get(field)
-
-		var val float64
-		switch field.Kind() {
-		case protoreflect.FloatKind, protoreflect.DoubleKind:
-			val = value.Float()
-		case protoreflect.Int32Kind, protoreflect.Int64Kind:
-			val = float64(value.Int())
-		case protoreflect.BoolKind:
-			val = 0
-			if value.Bool() {
-				val = 1
-			}
-		default:
+	for _, metric := range m.metrics {
+		if metric.field.value == nil {
 			// Ignore unsupported types.
-			logger.Printf("unsupported type %s(%s)", field.JSONName(), field.Kind())
+			logger.Printf("unsupported type %s", metric.field.name)
 			continue
 		}
-
-		ch <- prometheus.MustNewConstMetric(
-			metric.desc,
-			metric.typ,
-			metric.metric.ConvertValue(val),
-			metric.labels...,
-		)
+		m.emit(ch, metric, metric.field.value(v))
 	}
 	m.overview.Observe(time.Since(start).Seconds())
 	m.overview.Collect(ch)
+	m.invalid.Collect(ch)
 }
 
-func newMetricSet[T proto.Message](ns string, fetcher func(context.Context) (T, error)) metricFetcher {
-	set := make(map[string]metricData)
-	descs := make(descriptions)
-
-	var v T
-	desc := v.ProtoReflect().Descriptor()
-	for i := 0; i < desc.Fields().Len(); i++ {
-		field := desc.Fields().Get(i)
-		opts := field.Options().(*descriptorpb.FieldOptions)
-		ext, ok := proto.GetExtension(opts, E_Prometheus).(*Metric)
-		if !ok || ext.GetName() == "" {
+// observe adds a sample of T taken at t between scrapes to the aggregates of
+// its metrics.
+func (m *metricSet[T]) observe(t time.Time, v T) {
+	for _, metric := range m.metrics {
+		if metric.field.value == nil || (metric.histogram == nil && metric.minmax == nil) {
 			continue
 		}
-		if ext.GetSkip() {
-			set[field.JSONName()] = metricData{metric: ext}
+		val, _, keep := metric.metric.Validate(metric.field.value(v))
+		if !keep || math.IsNaN(val) {
 			continue
 		}
-
-		metric := metricData{
-			metric: ext,
-			desc:   descs.getDescription(ext, ns),
-			labels: ext.LabelValues(),
+		if metric.histogram != nil {
+			// Series are created on first use, so nothing is exported
+			// until samples are observed.
+			metric.histogram.WithLabelValues(metric.labels...).Observe(val)
 		}
+		if metric.minmax != nil {
+			metric.minmax.observe(t, val)
+		}
+	}
+}
 
-		switch ext.GetType() {
-		case Metric_COUNTER:
-			metric.typ = prometheus.CounterValue
-		case Metric_GAUGE:
-			metric.typ = prometheus.GaugeValue
-		default:
-			panic("unknown metric type")
+// emit validates and converts a raw value and sends it as a metric.
+func (m *metricSet[T]) emit(ch chan<- prometheus.Metric, metric metricData[T], raw float64) {
+	if metric.metric.GetMonotonic() {
+		var wrap float64
+		if metric.field.int32 {
+			wrap = int32Wrap
 		}
+		raw = m.counters.adjust(metric.key, metric.metric.GetName(), raw, wrap)
+	}
+	val, valid, keep := metric.metric.Validate(raw)
+	if !valid {
+		m.invalid.WithLabelValues(metric.metric.GetName()).Inc()
+	}
+	if !keep {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		metric.desc,
+		metric.typ,
+		val,
+		metric.labels...,
+	)
+}
 
-		name := field.JSONName()
-		set[name] = metric
+func newMetricSet[T proto.Message](ns string, fetcher func(context.Context) (T, error), counters *counterGuard, labels prometheus.Labels, window time.Duration) metricFetcher {
+	var set []metricData[T]
+	descs := descriptions{descs: make(map[string]*prometheus.Desc), labels: labels}
+	aggregates := newAggregates(labels, window)
+	for _, field := range messageFields[T]() {
+		metric := newMetricData(field, ns, descs)
+		for _, agg := range field.metric.GetAggregate() {
+			switch agg {
+			case Metric_HISTOGRAM:
+				metric.histogram = aggregates.histogram(field.metric, ns)
+			case Metric_MINMAX:
+				metric.minmax = aggregates.minMax(field.metric, ns)
+			}
+		}
+		set = append(set, metric)
+	}
+	setLabels := prometheus.Labels{"metric_set": ns}
+	for k, v := range labels {
+		setLabels[k] = v
 	}
 
 	return &metricSet[T]{
-		metrics: set,
-		fetcher: fetcher,
+		metrics:  set,
+		fetcher:  fetcher,
+		counters: counters,
+
+		aggregates: aggregates,
+		now:        time.Now,
 		overview: prometheus.NewSummary(prometheus.SummaryOpts{
 			Namespace: "wallconnector",
 			Subsystem: "scrape",
 			Name:      "fetch_duration_seconds",
 			Help:      "Duration of a scrape for a metric set.",
 
-			ConstLabels: map[string]string{
-				"metric_set": ns,
-			},
+			ConstLabels: setLabels,
 		}),
+		invalid: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "wallconnector",
+			Name:      "invalid_sample_total",
+			Help:      "Number of samples reported by the device outside of their valid range.",
+
+			ConstLabels: setLabels,
+		}, []string{"metric"}),
+	}
+}
+
+func newMetricData[T any](field promField[T], ns string, descs descriptions) metricData[T] {
+	ext := field.metric
+	metric := metricData[T]{
+		metric: ext,
+		field:  field,
+		desc:   descs.getDescription(ext, ns),
+		labels: ext.LabelValues(),
+		key:    prometheus.BuildFQName("wallconnector", ns, ext.GetName()) + "{" + strings.Join(ext.GetLabels(), ",") + "}",
+	}
+
+	switch ext.GetType() {
+	case Metric_COUNTER:
+		metric.typ = prometheus.CounterValue
+	case Metric_GAUGE:
+		metric.typ = prometheus.GaugeValue
+	default:
+		panic("unknown metric type")
 	}
+	return metric
 }
 
-type descriptions map[string]*prometheus.Desc
+// descriptions shares the descriptions of metrics with the same name.
+type descriptions struct {
+	descs map[string]*prometheus.Desc
+	// Constant labels of every description.
+	labels prometheus.Labels
+}
 
 func (m *Metric) LabelKeys() []string {
 	keys := make([]string, 0, len(m.GetLabels()))
@@ -219,7 +362,12 @@ func (m *Metric) LabelValues() []string {
 }
 
 const (
-	whToJoules = 3600
+	whToJoules  = 3600
+	kwhToJoules = 1000 * whToJoules
+	microVolts  = 1e-6
+	milliSecs   = 1e-3
+	percent     = 1e-2
+	zeroCelsius = 273.15
 )
 
 func (m *Metric) ConvertValue(v float64) float64 {
@@ -230,14 +378,68 @@ func (m *Metric) ConvertValue(v float64) float64 {
 		return 1 / v
 	case Conversion_WH_TO_J:
 		return v * whToJoules
+	case Conversion_KWH_TO_J:
+		return v * kwhToJoules
+	case Conversion_UV_TO_V:
+		return v * microVolts
+	case Conversion_MS_TO_S:
+		return v * milliSecs
+	case Conversion_PERCENT_TO_RATIO:
+		return v * percent
+	case Conversion_F_TO_C:
+		return (v - 32) * 5 / 9
+	case Conversion_K_TO_C:
+		return v - zeroCelsius
+	case Conversion_SCALE_OFFSET:
+		scale := m.GetScale()
+		if scale == 0 {
+			scale = 1
+		}
+		return v*scale + m.GetOffset()
 	default:
 		panic("unknown conversion")
 	}
 }
 
+// Validate checks a raw value against the valid range of the metric and
+// converts it. valid is false when the value was out of range or converted to
+// a non-finite value, in which case the invalid policy of the metric has been
+// applied and keep reports whether the resulting value should be exported.
+func (m *Metric) Validate(v float64) (val float64, valid, keep bool) {
+	policy := m.GetInvalid()
+	valid = true
+	if r := m.GetValid(); r != nil {
+		if r.Min != nil && v < r.GetMin() {
+			valid, v = false, r.GetMin()
+		}
+		if r.Max != nil && v > r.GetMax() {
+			valid, v = false, r.GetMax()
+		}
+	}
+	if !valid {
+		switch policy {
+		case Metric_NAN:
+			return math.NaN(), false, true
+		case Metric_CLAMP:
+			// v has already been clamped to the range.
+		default:
+			return 0, false, false
+		}
+	}
+
+	val = m.ConvertValue(v)
+	if math.IsInf(val, 0) || math.IsNaN(val) {
+		if policy == Metric_NAN {
+			return math.NaN(), false, true
+		}
+		return 0, false, false
+	}
+	return val, valid, true
+}
+
 func (d descriptions) getDescription(v *Metric, ns string) *prometheus.Desc {
 	name := prometheus.BuildFQName("wallconnector", ns, v.GetName())
-	if desc, ok := d[name]; ok {
+	if desc, ok := d.descs[name]; ok {
 		return desc
 	}
 
@@ -245,8 +447,8 @@ func (d descriptions) getDescription(v *Metric, ns string) *prometheus.Desc {
 		name,
 		v.GetHelp(),
 		v.LabelKeys(),
-		nil,
+		d.labels,
 	)
-	d[name] = desc
+	d.descs[name] = desc
 	return desc
 }
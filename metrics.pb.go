@@ -29,6 +29,20 @@ const (
 	Conversion_INVERSE Conversion = 1
 	// Convert from watt-hours to joules.
 	Conversion_WH_TO_J Conversion = 2
+	// Convert from kilowatt-hours to joules.
+	Conversion_KWH_TO_J Conversion = 3
+	// Convert from microvolts to volts.
+	Conversion_UV_TO_V Conversion = 4
+	// Convert from milliseconds to seconds.
+	Conversion_MS_TO_S Conversion = 5
+	// Convert from a percentage (0-100) to a ratio (0-1).
+	Conversion_PERCENT_TO_RATIO Conversion = 6
+	// Convert from degrees Fahrenheit to degrees Celsius.
+	Conversion_F_TO_C Conversion = 7
+	// Convert from kelvin to degrees Celsius.
+	Conversion_K_TO_C Conversion = 8
+	// Compute value * scale + offset using the scale and offset of the metric.
+	Conversion_SCALE_OFFSET Conversion = 9
 )
 
 // Enum value maps for Conversion.
@@ -37,11 +51,25 @@ var (
 		0: "NONE",
 		1: "INVERSE",
 		2: "WH_TO_J",
+		3: "KWH_TO_J",
+		4: "UV_TO_V",
+		5: "MS_TO_S",
+		6: "PERCENT_TO_RATIO",
+		7: "F_TO_C",
+		8: "K_TO_C",
+		9: "SCALE_OFFSET",
 	}
 	Conversion_value = map[string]int32{
-		"NONE":    0,
-		"INVERSE": 1,
-		"WH_TO_J": 2,
+		"NONE":             0,
+		"INVERSE":          1,
+		"WH_TO_J":          2,
+		"KWH_TO_J":         3,
+		"UV_TO_V":          4,
+		"MS_TO_S":          5,
+		"PERCENT_TO_RATIO": 6,
+		"F_TO_C":           7,
+		"K_TO_C":           8,
+		"SCALE_OFFSET":     9,
 	}
 )
 
@@ -118,6 +146,113 @@ func (Metric_Type) EnumDescriptor() ([]byte, []int) {
 	return file_metrics_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type Metric_InvalidPolicy int32
+
+const (
+	// Don't export the sample.
+	Metric_DROP Metric_InvalidPolicy = 0
+	// Export the sample as NaN.
+	Metric_NAN Metric_InvalidPolicy = 1
+	// Clamp the raw value to the valid range. Non-finite values are dropped.
+	Metric_CLAMP Metric_InvalidPolicy = 2
+)
+
+// Enum value maps for Metric_InvalidPolicy.
+var (
+	Metric_InvalidPolicy_name = map[int32]string{
+		0: "DROP",
+		1: "NAN",
+		2: "CLAMP",
+	}
+	Metric_InvalidPolicy_value = map[string]int32{
+		"DROP":  0,
+		"NAN":   1,
+		"CLAMP": 2,
+	}
+)
+
+func (x Metric_InvalidPolicy) Enum() *Metric_InvalidPolicy {
+	p := new(Metric_InvalidPolicy)
+	*p = x
+	return p
+}
+
+func (x Metric_InvalidPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Metric_InvalidPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_metrics_proto_enumTypes[2].Descriptor()
+}
+
+func (Metric_InvalidPolicy) Type() protoreflect.EnumType {
+	return &file_metrics_proto_enumTypes[2]
+}
+
+func (x Metric_InvalidPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Metric_InvalidPolicy.Descriptor instead.
+func (Metric_InvalidPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_metrics_proto_rawDescGZIP(), []int{0, 1}
+}
+
+// Export the distribution of a GAUGE between scrapes, from the samples
+// fed to the collector with WithVitalsSamples, in addition to its latest
+// value. Only supported on fields and derived metrics of Vitals.
+type Metric_Aggregate int32
+
+const (
+	Metric_NONE Metric_Aggregate = 0
+	// A native histogram named <name>_distribution.
+	Metric_HISTOGRAM Metric_Aggregate = 1
+	// Gauges named <name>_min and <name>_max of the samples since the
+	// last scrape.
+	Metric_MINMAX Metric_Aggregate = 2
+)
+
+// Enum value maps for Metric_Aggregate.
+var (
+	Metric_Aggregate_name = map[int32]string{
+		0: "NONE",
+		1: "HISTOGRAM",
+		2: "MINMAX",
+	}
+	Metric_Aggregate_value = map[string]int32{
+		"NONE":      0,
+		"HISTOGRAM": 1,
+		"MINMAX":    2,
+	}
+)
+
+func (x Metric_Aggregate) Enum() *Metric_Aggregate {
+	p := new(Metric_Aggregate)
+	*p = x
+	return p
+}
+
+func (x Metric_Aggregate) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Metric_Aggregate) Descriptor() protoreflect.EnumDescriptor {
+	return file_metrics_proto_enumTypes[3].Descriptor()
+}
+
+func (Metric_Aggregate) Type() protoreflect.EnumType {
+	return &file_metrics_proto_enumTypes[3]
+}
+
+func (x Metric_Aggregate) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Metric_Aggregate.Descriptor instead.
+func (Metric_Aggregate) EnumDescriptor() ([]byte, []int) {
+	return file_metrics_proto_rawDescGZIP(), []int{0, 2}
+}
+
 type Metric struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -128,6 +263,27 @@ type Metric struct {
 	Help       string      `protobuf:"bytes,3,opt,name=help,proto3" json:"help,omitempty"`
 	Labels     []string    `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
 	Conversion Conversion  `protobuf:"varint,5,opt,name=conversion,proto3,enum=com.winstondurand.wallconnector.Conversion" json:"conversion,omitempty"`
+	// Compute the value from other fields of the same message rather than
+	// reading it from a field. Only valid on derived metrics, see below.
+	//
+	// Expressions support +, -, *, / and parentheses over numeric literals
+	// and the raw (unconverted) values of fields, referenced by their proto
+	// name, e.g. "voltageA_v * currentA_a". Booleans evaluate to 0 or 1.
+	Expr string `protobuf:"bytes,6,opt,name=expr,proto3" json:"expr,omitempty"`
+	// Parameters for the SCALE_OFFSET conversion. An unset scale is treated
+	// as 1 so that offset can be used on its own.
+	Scale   float64              `protobuf:"fixed64,7,opt,name=scale,proto3" json:"scale,omitempty"`
+	Offset  float64              `protobuf:"fixed64,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	Valid   *Metric_Range        `protobuf:"bytes,9,opt,name=valid,proto3" json:"valid,omitempty"`
+	Invalid Metric_InvalidPolicy `protobuf:"varint,10,opt,name=invalid,proto3,enum=com.winstondurand.wallconnector.Metric_InvalidPolicy" json:"invalid,omitempty"`
+	// Keep a COUNTER from going backwards when the device resets it or it
+	// overflows, by adding an offset to all subsequent values.
+	Monotonic bool `protobuf:"varint,11,opt,name=monotonic,proto3" json:"monotonic,omitempty"`
+	// Ignore changes to the raw value smaller than this when diffing
+	// successive messages, e.g. 1 to ignore voltage jitter. Only valid on
+	// fields.
+	Deadband  float64            `protobuf:"fixed64,12,opt,name=deadband,proto3" json:"deadband,omitempty"`
+	Aggregate []Metric_Aggregate `protobuf:"varint,13,rep,packed,name=aggregate,proto3,enum=com.winstondurand.wallconnector.Metric_Aggregate" json:"aggregate,omitempty"`
 	// Skip this field when generating the prometheus metrics.
 	// Note: Even when this is set to true, the field still requires a name.
 	Skip bool `protobuf:"varint,20,opt,name=skip,proto3" json:"skip,omitempty"`
@@ -200,6 +356,62 @@ func (x *Metric) GetConversion() Conversion {
 	return Conversion_NONE
 }
 
+func (x *Metric) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *Metric) GetScale() float64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *Metric) GetOffset() float64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Metric) GetValid() *Metric_Range {
+	if x != nil {
+		return x.Valid
+	}
+	return nil
+}
+
+func (x *Metric) GetInvalid() Metric_InvalidPolicy {
+	if x != nil {
+		return x.Invalid
+	}
+	return Metric_DROP
+}
+
+func (x *Metric) GetMonotonic() bool {
+	if x != nil {
+		return x.Monotonic
+	}
+	return false
+}
+
+func (x *Metric) GetDeadband() float64 {
+	if x != nil {
+		return x.Deadband
+	}
+	return 0
+}
+
+func (x *Metric) GetAggregate() []Metric_Aggregate {
+	if x != nil {
+		return x.Aggregate
+	}
+	return nil
+}
+
 func (x *Metric) GetSkip() bool {
 	if x != nil {
 		return x.Skip
@@ -728,6 +940,65 @@ func (x *Wifi) GetInternet() bool {
 	return false
 }
 
+// Range of valid raw values, checked before any conversion. Values
+// outside of the range, or which convert to a non-finite value (e.g.
+// INVERSE of 0), are counted as invalid samples and handled according
+// to the invalid policy.
+type Metric_Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min *float64 `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max *float64 `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+}
+
+func (x *Metric_Range) Reset() {
+	*x = Metric_Range{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metric_Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metric_Range) ProtoMessage() {}
+
+func (x *Metric_Range) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metric_Range.ProtoReflect.Descriptor instead.
+func (*Metric_Range) Descriptor() ([]byte, []int) {
+	return file_metrics_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Metric_Range) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *Metric_Range) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
 var file_metrics_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
@@ -737,6 +1008,14 @@ var file_metrics_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50000,opt,name=prometheus",
 		Filename:      "metrics.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*Metric)(nil),
+		Field:         50001,
+		Name:          "com.winstondurand.wallconnector.derived",
+		Tag:           "bytes,50001,rep,name=derived",
+		Filename:      "metrics.proto",
+	},
 }
 
 // Extension fields to descriptorpb.FieldOptions.
@@ -745,6 +1024,12 @@ var (
 	E_Prometheus = &file_metrics_proto_extTypes[0]
 )
 
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// repeated com.winstondurand.wallconnector.Metric derived = 50001;
+	E_Derived = &file_metrics_proto_extTypes[1]
+)
+
 var File_metrics_proto protoreflect.FileDescriptor
 
 var file_metrics_proto_rawDesc = []byte{
@@ -753,7 +1038,7 @@ var file_metrics_proto_rawDesc = []byte{
 	0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72,
 	0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x8b, 0x02, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a,
+	0x74, 0x6f, 0x22, 0x96, 0x06, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x12, 0x40, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
 	0x2c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x74, 0x6f, 0x6e, 0x64, 0x75, 0x72,
@@ -767,306 +1052,385 @@ var file_metrics_proto_rawDesc = []byte{
 	0x6e, 0x64, 0x75, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e, 0x6e,
 	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
 	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
-	0x73, 0x6b, 0x69, 0x70, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70,
-	0x22, 0x1e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x41, 0x55, 0x47,
-	0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x01,
-	0x22, 0xf4, 0x12, 0x0a, 0x06, 0x56, 0x69, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x6a, 0x0a, 0x10, 0x63,
-	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x17, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x1a, 0x20, 0x57, 0x68, 0x65, 0x74, 0x68, 0x65, 0x72, 0x20, 0x74, 0x68,
-	0x65, 0x20, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x69, 0x73, 0x20, 0x63,
-	0x6c, 0x6f, 0x73, 0x65, 0x64, 0x2e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f,
-	0x72, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x6c, 0x0a, 0x11, 0x76, 0x65, 0x68, 0x69, 0x63,
-	0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x18, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
-	0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x1a, 0x1f, 0x57, 0x68, 0x65, 0x74, 0x68, 0x65, 0x72, 0x20, 0x61, 0x20, 0x76, 0x65,
-	0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x69, 0x73, 0x20, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x65, 0x64, 0x2e, 0x52, 0x10, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x60, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x5f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x42, 0x43, 0x82, 0xb5, 0x18, 0x3f, 0x0a, 0x15,
-	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x24, 0x54, 0x68, 0x65, 0x20, 0x64, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x20, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x08, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x12, 0x43, 0x0a, 0x06, 0x67, 0x72, 0x69, 0x64, 0x5f,
-	0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x42, 0x2c, 0x82, 0xb5, 0x18, 0x28, 0x0a, 0x0c, 0x67,
-	0x72, 0x69, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x1a, 0x18, 0x54, 0x68, 0x65,
-	0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20,
-	0x67, 0x72, 0x69, 0x64, 0x2e, 0x52, 0x05, 0x67, 0x72, 0x69, 0x64, 0x56, 0x12, 0x50, 0x0a, 0x07,
-	0x67, 0x72, 0x69, 0x64, 0x5f, 0x68, 0x7a, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x42, 0x37, 0x82,
-	0xb5, 0x18, 0x33, 0x0a, 0x13, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
-	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x1a, 0x54, 0x68, 0x65, 0x20, 0x66, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x67,
-	0x72, 0x69, 0x64, 0x2e, 0x28, 0x01, 0x52, 0x06, 0x67, 0x72, 0x69, 0x64, 0x48, 0x7a, 0x12, 0x72,
-	0x0a, 0x11, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x5f, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x42, 0x46, 0x82, 0xb5, 0x18, 0x42, 0x0a,
-	0x17, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x27, 0x54, 0x68, 0x65, 0x20, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77,
-	0x6e, 0x20, 0x62, 0x79, 0x20, 0x74, 0x68, 0x65, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
-	0x2e, 0x52, 0x0f, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x41, 0x12, 0x60, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x5f, 0x61,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x42, 0x41, 0x82, 0xb5, 0x18, 0x3d, 0x0a, 0x0c, 0x77, 0x61,
-	0x6c, 0x6c, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x24, 0x54, 0x68, 0x65, 0x20,
-	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72,
-	0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e,
-	0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x41, 0x52, 0x09, 0x63, 0x75, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x41, 0x41, 0x12, 0x60, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42,
-	0x5f, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x42, 0x41, 0x82, 0xb5, 0x18, 0x3d, 0x0a, 0x0c,
+	0x65, 0x78, 0x70, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x78, 0x70, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x43,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x74, 0x6f, 0x6e, 0x64, 0x75, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x12, 0x4f, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x74,
+	0x6f, 0x6e, 0x64, 0x75, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x07, 0x69, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e, 0x69,
+	0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6d, 0x6f, 0x6e, 0x6f, 0x74, 0x6f, 0x6e,
+	0x69, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x62, 0x61, 0x6e, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x62, 0x61, 0x6e, 0x64, 0x12, 0x4f,
+	0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x74, 0x6f, 0x6e, 0x64,
+	0x75, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73,
+	0x6b, 0x69, 0x70, 0x1a, 0x45, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x03,
+	0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e,
+	0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d,
+	0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78, 0x22, 0x1e, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x41, 0x55, 0x47, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x01, 0x22, 0x2d, 0x0a, 0x0d, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x44,
+	0x52, 0x4f, 0x50, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x41, 0x4e, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x43, 0x4c, 0x41, 0x4d, 0x50, 0x10, 0x02, 0x22, 0x30, 0x0a, 0x09, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x4d, 0x49, 0x4e, 0x4d, 0x41, 0x58, 0x10, 0x02, 0x22, 0xed, 0x16, 0x0a, 0x06,
+	0x56, 0x69, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x6a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a,
+	0x20, 0x57, 0x68, 0x65, 0x74, 0x68, 0x65, 0x72, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x69, 0x73, 0x20, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64,
+	0x2e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x6c, 0x6f, 0x73,
+	0x65, 0x64, 0x12, 0x6c, 0x0a, 0x11, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x42, 0x3f, 0x82,
+	0xb5, 0x18, 0x3b, 0x0a, 0x18, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x1f, 0x57,
+	0x68, 0x65, 0x74, 0x68, 0x65, 0x72, 0x20, 0x61, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x20, 0x69, 0x73, 0x20, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x2e, 0x52, 0x10,
+	0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x60, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x42, 0x43, 0x82, 0xb5, 0x18, 0x3f, 0x0a, 0x15, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x10, 0x01, 0x1a, 0x24, 0x54, 0x68, 0x65, 0x20, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x12, 0x4f, 0x0a, 0x06, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x76, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x42, 0x38, 0x82, 0xb5, 0x18, 0x34, 0x0a, 0x0c, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x76,
+	0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74,
+	0x61, 0x67, 0x65, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x67, 0x72, 0x69, 0x64, 0x2e,
+	0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, 0x6a, 0x01, 0x02, 0x52, 0x05, 0x67, 0x72,
+	0x69, 0x64, 0x56, 0x12, 0x6d, 0x0a, 0x07, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x68, 0x7a, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x42, 0x54, 0x82, 0xb5, 0x18, 0x50, 0x0a, 0x13, 0x67, 0x72, 0x69, 0x64,
+	0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a,
+	0x1a, 0x54, 0x68, 0x65, 0x20, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x20, 0x6f,
+	0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x67, 0x72, 0x69, 0x64, 0x2e, 0x28, 0x01, 0x4a, 0x12, 0x09,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x40, 0x11, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x51,
+	0x40, 0x61, 0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xa9, 0x3f, 0x52, 0x06, 0x67, 0x72, 0x69, 0x64,
+	0x48, 0x7a, 0x12, 0x7f, 0x0a, 0x11, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x42, 0x53, 0x82,
+	0xb5, 0x18, 0x4f, 0x0a, 0x17, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x27, 0x54, 0x68,
+	0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20,
+	0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x62, 0x79, 0x20, 0x74, 0x68, 0x65, 0x20, 0x76, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x2e, 0x61, 0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xb9, 0x3f, 0x6a, 0x02,
+	0x01, 0x02, 0x52, 0x0f, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x41, 0x12, 0x69, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x5f,
+	0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x42, 0x4a, 0x82, 0xb5, 0x18, 0x46, 0x0a, 0x0c, 0x77,
+	0x61, 0x6c, 0x6c, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x24, 0x54, 0x68, 0x65,
+	0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20, 0x64,
+	0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c,
+	0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x41, 0x61, 0x9a, 0x99, 0x99, 0x99, 0x99,
+	0x99, 0xb9, 0x3f, 0x52, 0x09, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x41, 0x12, 0x69,
+	0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x5f, 0x61, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x01, 0x42, 0x4a, 0x82, 0xb5, 0x18, 0x46, 0x0a, 0x0c, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x61,
+	0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x24, 0x54, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20,
+	0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x3a, 0x42, 0x61, 0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xb9, 0x3f, 0x52, 0x09,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x41, 0x12, 0x69, 0x0a, 0x0a, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x43, 0x5f, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x42, 0x4a, 0x82,
+	0xb5, 0x18, 0x46, 0x0a, 0x0c, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65,
+	0x73, 0x1a, 0x24, 0x54, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62,
+	0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x43,
+	0x61, 0x9a, 0x99, 0x99, 0x99, 0x99, 0x99, 0xb9, 0x3f, 0x52, 0x09, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x43, 0x41, 0x12, 0x69, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4e,
+	0x5f, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x42, 0x4a, 0x82, 0xb5, 0x18, 0x46, 0x0a, 0x0c,
 	0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x24, 0x54, 0x68,
 	0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20,
 	0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c,
-	0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x42, 0x52, 0x09, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x42, 0x41, 0x12, 0x60, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x43, 0x5f, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x42, 0x41, 0x82, 0xb5, 0x18, 0x3d,
-	0x0a, 0x0c, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73, 0x1a, 0x24,
-	0x54, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65, 0x69, 0x6e,
-	0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77,
-	0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x43, 0x52, 0x09, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x41, 0x12, 0x60, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x4e, 0x5f, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x42, 0x41, 0x82, 0xb5,
-	0x18, 0x3d, 0x0a, 0x0c, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x61, 0x6d, 0x70, 0x65, 0x72, 0x65, 0x73,
-	0x1a, 0x24, 0x54, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x62, 0x65,
-	0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65,
-	0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x4e, 0x52,
-	0x09, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4e, 0x41, 0x12, 0x52, 0x0a, 0x0a, 0x76, 0x6f,
-	0x6c, 0x74, 0x61, 0x67, 0x65, 0x41, 0x5f, 0x76, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x42, 0x33,
-	0x82, 0xb5, 0x18, 0x2f, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73,
-	0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x20, 0x61, 0x74,
-	0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73,
-	0x65, 0x3a, 0x41, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x41, 0x56, 0x12, 0x52,
-	0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x42, 0x5f, 0x76, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x01, 0x42, 0x33, 0x82, 0xb5, 0x18, 0x2f, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x76,
-	0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67,
-	0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07,
-	0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x42, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65,
-	0x42, 0x56, 0x12, 0x52, 0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x43, 0x5f, 0x76,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x42, 0x33, 0x82, 0xb5, 0x18, 0x2f, 0x0a, 0x0a, 0x77, 0x61,
-	0x6c, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f,
-	0x6c, 0x74, 0x61, 0x67, 0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c,
-	0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x43, 0x52, 0x09, 0x76, 0x6f, 0x6c,
-	0x74, 0x61, 0x67, 0x65, 0x43, 0x56, 0x12, 0x58, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x5f,
-	0x63, 0x6f, 0x69, 0x6c, 0x5f, 0x76, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x42, 0x36, 0x82, 0xb5,
-	0x18, 0x32, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x69, 0x6c, 0x5f, 0x76,
-	0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x1e, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67,
-	0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x20, 0x63,
-	0x6f, 0x69, 0x6c, 0x2e, 0x52, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x69, 0x6c, 0x56,
-	0x12, 0x64, 0x0a, 0x0b, 0x70, 0x63, 0x62, 0x61, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x18,
-	0x0f, 0x20, 0x01, 0x28, 0x01, 0x42, 0x44, 0x82, 0xb5, 0x18, 0x40, 0x0a, 0x0c, 0x74, 0x65, 0x6d,
-	0x70, 0x5f, 0x63, 0x65, 0x6c, 0x73, 0x69, 0x75, 0x73, 0x1a, 0x21, 0x54, 0x65, 0x6d, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x20, 0x61, 0x74, 0x20, 0x76, 0x61, 0x72, 0x69, 0x6f, 0x75,
-	0x73, 0x20, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x22, 0x0d, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x70, 0x63, 0x62, 0x61, 0x52, 0x09, 0x70, 0x63, 0x62,
-	0x61, 0x54, 0x65, 0x6d, 0x70, 0x43, 0x12, 0x6a, 0x0a, 0x0d, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65,
-	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x42, 0x46, 0x82,
-	0xb5, 0x18, 0x42, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x65, 0x6c, 0x73, 0x69, 0x75,
-	0x73, 0x1a, 0x21, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x20, 0x61,
-	0x74, 0x20, 0x76, 0x61, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x20, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2e, 0x22, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x68,
-	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x0b, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x54, 0x65, 0x6d,
-	0x70, 0x43, 0x12, 0x61, 0x0a, 0x0a, 0x6d, 0x63, 0x75, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63,
-	0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x42, 0x43, 0x82, 0xb5, 0x18, 0x3f, 0x0a, 0x0c, 0x74, 0x65,
-	0x6d, 0x70, 0x5f, 0x63, 0x65, 0x6c, 0x73, 0x69, 0x75, 0x73, 0x1a, 0x21, 0x54, 0x65, 0x6d, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x20, 0x61, 0x74, 0x20, 0x76, 0x61, 0x72, 0x69, 0x6f,
-	0x75, 0x73, 0x20, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x22, 0x0c, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x6d, 0x63, 0x75, 0x52, 0x08, 0x6d, 0x63, 0x75,
-	0x54, 0x65, 0x6d, 0x70, 0x43, 0x12, 0x62, 0x0a, 0x08, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f,
-	0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x42, 0x47, 0x82, 0xb5, 0x18, 0x43, 0x0a, 0x14, 0x75,
-	0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x29, 0x54, 0x68, 0x65, 0x20, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x20, 0x68,
-	0x61, 0x73, 0x20, 0x62, 0x65, 0x65, 0x6e, 0x20, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
-	0x52, 0x07, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x12, 0x5b, 0x0a, 0x13, 0x69, 0x6e, 0x70,
-	0x75, 0x74, 0x5f, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x75, 0x76,
-	0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x42, 0x2b, 0x82, 0xb5, 0x18, 0x27, 0x0a, 0x13, 0x69, 0x6e,
-	0x70, 0x75, 0x74, 0x5f, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x75,
-	0x76, 0x1a, 0x10, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x20, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70,
-	0x69, 0x6c, 0x65, 0x52, 0x11, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x68, 0x65, 0x72, 0x6d, 0x6f,
-	0x70, 0x69, 0x6c, 0x65, 0x55, 0x76, 0x12, 0x4d, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x78, 0x5f, 0x76,
-	0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x42, 0x36, 0x82, 0xb5, 0x18, 0x32, 0x0a, 0x16, 0x70, 0x72,
-	0x6f, 0x78, 0x69, 0x6d, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x76,
-	0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x50, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x69, 0x74, 0x79, 0x20,
-	0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05,
-	0x70, 0x72, 0x6f, 0x78, 0x56, 0x12, 0x4c, 0x0a, 0x0c, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x68,
-	0x69, 0x67, 0x68, 0x5f, 0x76, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x42, 0x2a, 0x82, 0xb5, 0x18,
-	0x26, 0x0a, 0x10, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x76, 0x6f,
-	0x6c, 0x74, 0x73, 0x1a, 0x12, 0x50, 0x69, 0x6c, 0x6f, 0x74, 0x20, 0x68, 0x69, 0x67, 0x68, 0x20,
-	0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x52, 0x0a, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x48, 0x69,
-	0x67, 0x68, 0x56, 0x12, 0x48, 0x0a, 0x0b, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x6c, 0x6f, 0x77,
-	0x5f, 0x76, 0x18, 0x16, 0x20, 0x01, 0x28, 0x01, 0x42, 0x28, 0x82, 0xb5, 0x18, 0x24, 0x0a, 0x0f,
-	0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a,
-	0x11, 0x50, 0x69, 0x6c, 0x6f, 0x74, 0x20, 0x6c, 0x6f, 0x77, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61,
-	0x67, 0x65, 0x52, 0x09, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x4c, 0x6f, 0x77, 0x56, 0x12, 0x82, 0x01,
-	0x0a, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79,
-	0x5f, 0x77, 0x68, 0x18, 0x17, 0x20, 0x01, 0x28, 0x01, 0x42, 0x56, 0x82, 0xb5, 0x18, 0x52, 0x0a,
-	0x1b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f,
-	0x6a, 0x6f, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x2f,
-	0x54, 0x68, 0x65, 0x20, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x75,
-	0x6d, 0x65, 0x64, 0x20, 0x64, 0x75, 0x72, 0x69, 0x6e, 0x67, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x28,
-	0x02, 0x52, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x65, 0x72, 0x67, 0x79,
-	0x57, 0x68, 0x12, 0x5a, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x42, 0x35, 0x82, 0xb5, 0x18, 0x31, 0x0a,
-	0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x20,
-	0x54, 0x68, 0x65, 0x20, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68,
-	0x65, 0x20, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x47,
-	0x0a, 0x0a, 0x65, 0x76, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x19, 0x20, 0x01,
-	0x28, 0x05, 0x42, 0x28, 0x82, 0xb5, 0x18, 0x24, 0x0a, 0x0a, 0x65, 0x76, 0x73, 0x65, 0x5f, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x1a, 0x16, 0x54, 0x68, 0x65, 0x20, 0x73, 0x74, 0x61, 0x74, 0x65, 0x20,
-	0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x45, 0x56, 0x53, 0x45, 0x2e, 0x52, 0x09, 0x65, 0x76,
-	0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3e, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65,
-	0x6e, 0x74, 0x5f, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x05, 0x42,
-	0x17, 0x82, 0xb5, 0x18, 0x13, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61,
-	0x6c, 0x65, 0x72, 0x74, 0x73, 0xa0, 0x01, 0x01, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x22, 0xf3, 0x0b, 0x0a, 0x08, 0x4c, 0x69, 0x66, 0x65,
-	0x74, 0x69, 0x6d, 0x65, 0x12, 0xa9, 0x01, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
-	0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42,
-	0x7e, 0x82, 0xb5, 0x18, 0x7a, 0x0a, 0x16, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72,
-	0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a,
-	0x5e, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20, 0x74, 0x75, 0x72, 0x6e, 0x65,
-	0x64, 0x20, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x20, 0x6f, 0x6e, 0x2f, 0x6f, 0x66, 0x66, 0x20, 0x74,
-	0x6f, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x52,
-	0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73,
-	0x12, 0xde, 0x01, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63,
-	0x79, 0x63, 0x6c, 0x65, 0x73, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x42, 0xa5, 0x01, 0x82, 0xb5, 0x18, 0xa0, 0x01, 0x0a, 0x1d, 0x63, 0x6f, 0x6e, 0x74,
+	0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x4e, 0x61, 0x9a, 0x99, 0x99, 0x99,
+	0x99, 0x99, 0xb9, 0x3f, 0x52, 0x09, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4e, 0x41, 0x12,
+	0x5e, 0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x41, 0x5f, 0x76, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x01, 0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f,
+	0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61,
+	0x67, 0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22,
+	0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x41, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0,
+	0x3f, 0x6a, 0x01, 0x02, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x41, 0x56, 0x12,
+	0x5e, 0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x42, 0x5f, 0x76, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x01, 0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f,
+	0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61,
+	0x67, 0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22,
+	0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x42, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0,
+	0x3f, 0x6a, 0x01, 0x02, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x42, 0x56, 0x12,
+	0x5e, 0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x43, 0x5f, 0x76, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x01, 0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f,
+	0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x54, 0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61,
+	0x67, 0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22,
+	0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x43, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0,
+	0x3f, 0x6a, 0x01, 0x02, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x43, 0x56, 0x12,
+	0x58, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x69, 0x6c, 0x5f, 0x76, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x01, 0x42, 0x36, 0x82, 0xb5, 0x18, 0x32, 0x0a, 0x10, 0x72, 0x65, 0x6c,
+	0x61, 0x79, 0x5f, 0x63, 0x6f, 0x69, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x1e, 0x54,
+	0x68, 0x65, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x20, 0x63, 0x6f, 0x69, 0x6c, 0x2e, 0x52, 0x0a, 0x72,
+	0x65, 0x6c, 0x61, 0x79, 0x43, 0x6f, 0x69, 0x6c, 0x56, 0x12, 0x6d, 0x0a, 0x0b, 0x70, 0x63, 0x62,
+	0x61, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x42, 0x4d,
+	0x82, 0xb5, 0x18, 0x49, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x65, 0x6c, 0x73, 0x69,
+	0x75, 0x73, 0x1a, 0x21, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x20,
+	0x61, 0x74, 0x20, 0x76, 0x61, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x20, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x22, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x3a,
+	0x70, 0x63, 0x62, 0x61, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xe0, 0x3f, 0x52, 0x09, 0x70,
+	0x63, 0x62, 0x61, 0x54, 0x65, 0x6d, 0x70, 0x43, 0x12, 0x73, 0x0a, 0x0d, 0x68, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x42,
+	0x4f, 0x82, 0xb5, 0x18, 0x4b, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x65, 0x6c, 0x73,
+	0x69, 0x75, 0x73, 0x1a, 0x21, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x20, 0x61, 0x74, 0x20, 0x76, 0x61, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x20, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x22, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x3a, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xe0, 0x3f,
+	0x52, 0x0b, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x43, 0x12, 0x6a, 0x0a,
+	0x0a, 0x6d, 0x63, 0x75, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x01, 0x42, 0x4c, 0x82, 0xb5, 0x18, 0x48, 0x0a, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x63, 0x65,
+	0x6c, 0x73, 0x69, 0x75, 0x73, 0x1a, 0x21, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x20, 0x61, 0x74, 0x20, 0x76, 0x61, 0x72, 0x69, 0x6f, 0x75, 0x73, 0x20, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x22, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x3a, 0x6d, 0x63, 0x75, 0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xe0, 0x3f, 0x52,
+	0x08, 0x6d, 0x63, 0x75, 0x54, 0x65, 0x6d, 0x70, 0x43, 0x12, 0x62, 0x0a, 0x08, 0x75, 0x70, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x42, 0x47, 0x82, 0xb5, 0x18,
+	0x43, 0x0a, 0x14, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x29, 0x54, 0x68, 0x65, 0x20, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x62, 0x65, 0x65, 0x6e, 0x20, 0x72, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x2e, 0x52, 0x07, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x12, 0x60, 0x0a,
+	0x13, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70, 0x69, 0x6c,
+	0x65, 0x5f, 0x75, 0x76, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x42, 0x30, 0x82, 0xb5, 0x18, 0x2c,
+	0x0a, 0x16, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70, 0x69,
+	0x6c, 0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x10, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x20,
+	0x74, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70, 0x69, 0x6c, 0x65, 0x28, 0x04, 0x52, 0x11, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x54, 0x68, 0x65, 0x72, 0x6d, 0x6f, 0x70, 0x69, 0x6c, 0x65, 0x55, 0x76, 0x12,
+	0x4d, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x78, 0x5f, 0x76, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x42,
+	0x36, 0x82, 0xb5, 0x18, 0x32, 0x0a, 0x16, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x69, 0x74, 0x79,
+	0x5f, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x18, 0x50,
+	0x72, 0x6f, 0x78, 0x69, 0x6d, 0x69, 0x74, 0x79, 0x20, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x20,
+	0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x56, 0x12, 0x4c,
+	0x0a, 0x0c, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x76, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x01, 0x42, 0x2a, 0x82, 0xb5, 0x18, 0x26, 0x0a, 0x10, 0x70, 0x69, 0x6c, 0x6f,
+	0x74, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x12, 0x50, 0x69,
+	0x6c, 0x6f, 0x74, 0x20, 0x68, 0x69, 0x67, 0x68, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65,
+	0x52, 0x0a, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x48, 0x69, 0x67, 0x68, 0x56, 0x12, 0x48, 0x0a, 0x0b,
+	0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x76, 0x18, 0x16, 0x20, 0x01, 0x28,
+	0x01, 0x42, 0x28, 0x82, 0xb5, 0x18, 0x24, 0x0a, 0x0f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x5f, 0x6c,
+	0x6f, 0x77, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x73, 0x1a, 0x11, 0x50, 0x69, 0x6c, 0x6f, 0x74, 0x20,
+	0x6c, 0x6f, 0x77, 0x20, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x52, 0x09, 0x70, 0x69, 0x6c,
+	0x6f, 0x74, 0x4c, 0x6f, 0x77, 0x56, 0x12, 0x82, 0x01, 0x0a, 0x11, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x77, 0x68, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x01, 0x42, 0x56, 0x82, 0xb5, 0x18, 0x52, 0x0a, 0x1b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x6a, 0x6f, 0x75, 0x6c, 0x65, 0x73, 0x5f,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x2f, 0x54, 0x68, 0x65, 0x20, 0x65, 0x6e, 0x65,
+	0x72, 0x67, 0x79, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x20, 0x64, 0x75, 0x72,
+	0x69, 0x6e, 0x67, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x20,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x28, 0x02, 0x52, 0x0f, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x57, 0x68, 0x12, 0x5a, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x05, 0x42, 0x35, 0x82, 0xb5, 0x18, 0x31, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x20, 0x54, 0x68, 0x65, 0x20, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x47, 0x0a, 0x0a, 0x65, 0x76, 0x73, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x05, 0x42, 0x28, 0x82, 0xb5, 0x18,
+	0x24, 0x0a, 0x0a, 0x65, 0x76, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x16, 0x54,
+	0x68, 0x65, 0x20, 0x73, 0x74, 0x61, 0x74, 0x65, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20,
+	0x45, 0x56, 0x53, 0x45, 0x2e, 0x52, 0x09, 0x65, 0x76, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x3e, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6c, 0x65, 0x72,
+	0x74, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x05, 0x42, 0x17, 0x82, 0xb5, 0x18, 0x13, 0x0a, 0x0e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0xa0, 0x01,
+	0x01, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73,
+	0x3a, 0xd8, 0x02, 0x8a, 0xb5, 0x18, 0x52, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x61,
+	0x74, 0x74, 0x73, 0x1a, 0x22, 0x54, 0x68, 0x65, 0x20, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x20, 0x62,
+	0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x41,
+	0x32, 0x17, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x41, 0x5f, 0x76, 0x20, 0x2a, 0x20, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x5f, 0x61, 0x8a, 0xb5, 0x18, 0x52, 0x0a, 0x0a, 0x77,
+	0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73, 0x1a, 0x22, 0x54, 0x68, 0x65, 0x20, 0x70,
+	0x6f, 0x77, 0x65, 0x72, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e,
+	0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61, 0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x3a, 0x42, 0x32, 0x17, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x42,
+	0x5f, 0x76, 0x20, 0x2a, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x5f, 0x61, 0x8a,
+	0xb5, 0x18, 0x52, 0x0a, 0x0a, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73, 0x1a,
+	0x22, 0x54, 0x68, 0x65, 0x20, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67,
+	0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20, 0x61, 0x74, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x61,
+	0x6c, 0x6c, 0x2e, 0x22, 0x07, 0x70, 0x68, 0x61, 0x73, 0x65, 0x3a, 0x43, 0x32, 0x17, 0x76, 0x6f,
+	0x6c, 0x74, 0x61, 0x67, 0x65, 0x43, 0x5f, 0x76, 0x20, 0x2a, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x43, 0x5f, 0x61, 0x8a, 0xb5, 0x18, 0x52, 0x0a, 0x0d, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x5f, 0x77, 0x61, 0x74, 0x74, 0x73, 0x1a, 0x25, 0x54, 0x68, 0x65, 0x20, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x20, 0x62, 0x65, 0x69, 0x6e, 0x67, 0x20, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x20,
+	0x62, 0x79, 0x20, 0x74, 0x68, 0x65, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x32,
+	0x1a, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x76, 0x20, 0x2a, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x22, 0x86, 0x0c, 0x0a, 0x08,
+	0x4c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x12, 0xac, 0x01, 0x0a, 0x10, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x42, 0x80, 0x01, 0x82, 0xb5, 0x18, 0x7c, 0x0a, 0x16, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x10, 0x01, 0x1a, 0x5e, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68,
+	0x65, 0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f,
+	0x66, 0x20, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c,
+	0x6c, 0x20, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20,
+	0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x20, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x20, 0x6f, 0x6e, 0x2f,
+	0x6f, 0x66, 0x66, 0x20, 0x74, 0x6f, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x2e, 0x58, 0x01, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0xe0, 0x01, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x74,
 	0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x5f, 0x6c, 0x6f, 0x61,
-	0x64, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x7d, 0x54, 0x68, 0x69,
+	0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0xa7, 0x01, 0x82, 0xb5, 0x18, 0xa2,
+	0x01, 0x0a, 0x1d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x73, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x10, 0x01, 0x1a, 0x7d, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20, 0x74, 0x75,
+	0x72, 0x6e, 0x65, 0x64, 0x20, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x20, 0x6f, 0x6e, 0x2f, 0x6f, 0x66,
+	0x66, 0x20, 0x74, 0x6f, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x20, 0x77, 0x68, 0x69, 0x6c, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x20, 0x77, 0x61, 0x73, 0x20, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67,
+	0x2e, 0x58, 0x01, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x79,
+	0x63, 0x6c, 0x65, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x8b, 0x01, 0x0a, 0x0b, 0x61,
+	0x6c, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x42, 0x6a, 0x82, 0xb5, 0x18, 0x66, 0x0a, 0x11, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x4d, 0x54, 0x68, 0x69,
+	0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x20,
+	0x74, 0x68, 0x61, 0x74, 0x20, 0x68, 0x61, 0x76, 0x65, 0x20, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x64, 0x20, 0x6f, 0x6e, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x58, 0x01, 0x52, 0x0a, 0x61, 0x6c,
+	0x65, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0xd7, 0x01, 0x0a, 0x16, 0x74, 0x68, 0x65,
+	0x72, 0x6d, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x6c, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0xa0, 0x01, 0x82, 0xb5, 0x18, 0x9b,
+	0x01, 0x0a, 0x1c, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x6c, 0x64, 0x62,
+	0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10,
+	0x01, 0x1a, 0x77, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20, 0x72, 0x65, 0x64,
+	0x75, 0x63, 0x65, 0x64, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x20, 0x74, 0x6f, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x20, 0x64, 0x75, 0x65, 0x20, 0x74, 0x6f, 0x20, 0x68, 0x69, 0x67, 0x68, 0x20, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x58, 0x01, 0x52, 0x14, 0x74, 0x68,
+	0x65, 0x72, 0x6d, 0x61, 0x6c, 0x46, 0x6f, 0x6c, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x52, 0x0a, 0x10, 0x61, 0x76, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75,
+	0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x42, 0x28, 0x82, 0xb5,
+	0x18, 0x24, 0x0a, 0x18, 0x61, 0x76, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a, 0x08, 0x55, 0x6e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x2e, 0x52, 0x0e, 0x61, 0x76, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x75, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x88, 0x01, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x72, 0x67,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x42, 0x63,
+	0x82, 0xb5, 0x18, 0x5f, 0x0a, 0x13, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x44, 0x54, 0x68, 0x69,
 	0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e,
 	0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x20, 0x79,
-	0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x20, 0x70, 0x6f,
-	0x77, 0x65, 0x72, 0x20, 0x6f, 0x6e, 0x2f, 0x6f, 0x66, 0x66, 0x20, 0x74, 0x6f, 0x20, 0x79, 0x6f,
-	0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x77, 0x68, 0x69, 0x6c, 0x65,
-	0x20, 0x74, 0x68, 0x65, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x77, 0x61, 0x73,
-	0x20, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x74,
-	0x61, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x4c, 0x6f, 0x61, 0x64, 0x65,
-	0x64, 0x12, 0x89, 0x01, 0x0a, 0x0b, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x68, 0x82, 0xb5, 0x18, 0x64, 0x0a, 0x11, 0x61,
-	0x6c, 0x65, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x10, 0x01, 0x1a, 0x4d, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20,
-	0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x20, 0x74, 0x68, 0x61, 0x74, 0x20, 0x68, 0x61, 0x76, 0x65,
-	0x20, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x20, 0x6f, 0x6e, 0x20, 0x79, 0x6f, 0x75,
-	0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x2e, 0x52, 0x0a, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0xd5, 0x01,
-	0x0a, 0x16, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x6c, 0x64, 0x62, 0x61,
-	0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x9e,
-	0x01, 0x82, 0xb5, 0x18, 0x99, 0x01, 0x0a, 0x1c, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x5f,
-	0x66, 0x6f, 0x6c, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x77, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20,
-	0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57,
-	0x61, 0x6c, 0x6c, 0x20, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61,
-	0x73, 0x20, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x64, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x20, 0x74, 0x6f, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65,
-	0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x64, 0x75, 0x65, 0x20, 0x74, 0x6f, 0x20, 0x68, 0x69, 0x67,
-	0x68, 0x20, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x52,
-	0x14, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x61, 0x6c, 0x46, 0x6f, 0x6c, 0x64, 0x62, 0x61, 0x63, 0x6b,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x52, 0x0a, 0x10, 0x61, 0x76, 0x67, 0x5f, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x75, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x42,
-	0x28, 0x82, 0xb5, 0x18, 0x24, 0x0a, 0x18, 0x61, 0x76, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x75, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x1a,
-	0x08, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x2e, 0x52, 0x0e, 0x61, 0x76, 0x67, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x75, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x86, 0x01, 0x0a, 0x0d, 0x63, 0x68,
-	0x61, 0x72, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x05, 0x42, 0x61, 0x82, 0xb5, 0x18, 0x5d, 0x0a, 0x13, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x44,
-	0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x68,
-	0x61, 0x73, 0x20, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x20, 0x63, 0x68, 0x61, 0x72, 0x67,
-	0x69, 0x6e, 0x67, 0x2e, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x73, 0x12, 0x79, 0x0a, 0x09, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x77, 0x68, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x03, 0x42, 0x5c, 0x82, 0xb5, 0x18, 0x58, 0x0a, 0x13, 0x65, 0x6e, 0x65,
-	0x72, 0x67, 0x79, 0x5f, 0x6a, 0x6f, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x10, 0x01, 0x1a, 0x3d, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20,
+	0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x20, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67,
+	0x2e, 0x58, 0x01, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x73, 0x12, 0x7b, 0x0a, 0x09, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x77, 0x68, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x5e, 0x82, 0xb5, 0x18, 0x5a, 0x0a, 0x13, 0x65, 0x6e, 0x65, 0x72,
+	0x67, 0x79, 0x5f, 0x6a, 0x6f, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10,
+	0x01, 0x1a, 0x3d, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x20, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x65,
+	0x6e, 0x65, 0x72, 0x67, 0x79, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x2e,
+	0x28, 0x02, 0x58, 0x01, 0x52, 0x08, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x57, 0x68, 0x12, 0x90,
+	0x01, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63,
+	0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x42, 0x65, 0x82, 0xb5, 0x18, 0x61, 0x0a,
+	0x16, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x43, 0x54, 0x68, 0x69, 0x73, 0x20,
+	0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x20, 0x79, 0x6f, 0x75,
+	0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x62, 0x65,
+	0x65, 0x6e, 0x20, 0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x20, 0x69, 0x6e, 0x2e, 0x58, 0x01,
+	0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65,
+	0x73, 0x12, 0x84, 0x01, 0x0a, 0x08, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x69, 0x82, 0xb5, 0x18, 0x65, 0x0a, 0x14, 0x75, 0x70, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x10, 0x01, 0x1a, 0x49, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20,
 	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20,
-	0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69,
-	0x63, 0x6c, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64,
-	0x2e, 0x28, 0x02, 0x52, 0x08, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x57, 0x68, 0x12, 0x8e, 0x01,
-	0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c,
-	0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x42, 0x63, 0x82, 0xb5, 0x18, 0x5f, 0x0a, 0x16,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73,
-	0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x43, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69,
-	0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x20, 0x79, 0x6f, 0x75, 0x72,
-	0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x62, 0x65, 0x65,
-	0x6e, 0x20, 0x70, 0x6c, 0x75, 0x67, 0x67, 0x65, 0x64, 0x20, 0x69, 0x6e, 0x2e, 0x52, 0x0f, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x82,
-	0x01, 0x0a, 0x08, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x03, 0x42, 0x67, 0x82, 0xb5, 0x18, 0x63, 0x0a, 0x14, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f,
-	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a,
-	0x49, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x20, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d,
-	0x65, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20, 0x62, 0x65, 0x65, 0x6e, 0x20, 0x70,
-	0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x20, 0x6f, 0x6e, 0x2e, 0x52, 0x07, 0x75, 0x70, 0x74, 0x69,
-	0x6d, 0x65, 0x53, 0x12, 0x87, 0x01, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x42, 0x63, 0x82, 0xb5, 0x18,
-	0x5f, 0x0a, 0x19, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73,
-	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x1a, 0x40,
-	0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x20, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x69, 0x6d, 0x65,
-	0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x20, 0x68, 0x61,
-	0x73, 0x20, 0x62, 0x65, 0x65, 0x6e, 0x20, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x2e,
-	0x52, 0x0b, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x22, 0x7a, 0x0a,
-	0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x72, 0x6d,
-	0x77, 0x61, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72,
-	0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xd3, 0x03, 0x0a, 0x04, 0x57, 0x69,
-	0x66, 0x69, 0x12, 0x69, 0x0a, 0x14, 0x77, 0x69, 0x66, 0x69, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x6c, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x42, 0x37, 0x82, 0xb5, 0x18, 0x33, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x5f, 0x73,
-	0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x1a, 0x20, 0x54, 0x68, 0x65, 0x20, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x6c, 0x20, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x20, 0x6f, 0x66, 0x20,
-	0x74, 0x68, 0x65, 0x20, 0x77, 0x69, 0x66, 0x69, 0x2e, 0x52, 0x12, 0x77, 0x69, 0x66, 0x69, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3e, 0x0a,
-	0x09, 0x77, 0x69, 0x66, 0x69, 0x5f, 0x72, 0x73, 0x73, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x42, 0x21, 0x82, 0xb5, 0x18, 0x1d, 0x0a, 0x04, 0x72, 0x73, 0x73, 0x69, 0x1a, 0x15, 0x54, 0x68,
-	0x65, 0x20, 0x52, 0x53, 0x53, 0x49, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x69,
-	0x66, 0x69, 0x2e, 0x52, 0x08, 0x77, 0x69, 0x66, 0x69, 0x52, 0x73, 0x73, 0x69, 0x12, 0x3a, 0x0a,
-	0x08, 0x77, 0x69, 0x66, 0x69, 0x5f, 0x73, 0x6e, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42,
-	0x1f, 0x82, 0xb5, 0x18, 0x1b, 0x0a, 0x03, 0x73, 0x6e, 0x72, 0x1a, 0x14, 0x54, 0x68, 0x65, 0x20,
-	0x53, 0x4e, 0x52, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x69, 0x66, 0x69, 0x2e,
-	0x52, 0x07, 0x77, 0x69, 0x66, 0x69, 0x53, 0x6e, 0x72, 0x12, 0x6f, 0x0a, 0x0e, 0x77, 0x69, 0x66,
-	0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x08, 0x42, 0x48, 0x82, 0xb5, 0x18, 0x44, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x1e, 0x57, 0x68, 0x65, 0x74,
-	0x68, 0x65, 0x72, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x69, 0x66, 0x69, 0x20, 0x69, 0x73, 0x20,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x2e, 0x22, 0x0f, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x77, 0x69, 0x66, 0x69, 0x52, 0x0d, 0x77, 0x69, 0x66,
-	0x69, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x73, 0x0a, 0x08, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x42, 0x57, 0x82, 0xb5,
-	0x18, 0x53, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x1a, 0x2b, 0x44, 0x6f, 0x65, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x64, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x20, 0x68, 0x61, 0x76, 0x65, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x65, 0x74, 0x20, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x2e,
-	0x22, 0x13, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x65, 0x74, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x2a,
-	0x30, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a,
+	0x74, 0x69, 0x6d, 0x65, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x57, 0x61, 0x6c, 0x6c, 0x20, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x20, 0x68, 0x61, 0x73, 0x20, 0x62, 0x65, 0x65,
+	0x6e, 0x20, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x20, 0x6f, 0x6e, 0x2e, 0x58, 0x01, 0x52,
+	0x07, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x12, 0x89, 0x01, 0x0a, 0x0d, 0x63, 0x68, 0x61,
+	0x72, 0x67, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05,
+	0x42, 0x65, 0x82, 0xb5, 0x18, 0x61, 0x0a, 0x19, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x10, 0x01, 0x1a, 0x40, 0x54, 0x68, 0x69, 0x73, 0x20, 0x69, 0x73, 0x20, 0x74, 0x68, 0x65,
+	0x20, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x20, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x20, 0x6f, 0x66,
+	0x20, 0x74, 0x69, 0x6d, 0x65, 0x20, 0x79, 0x6f, 0x75, 0x72, 0x20, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x20, 0x68, 0x61, 0x73, 0x20, 0x62, 0x65, 0x65, 0x6e, 0x20, 0x63, 0x68, 0x61, 0x72,
+	0x67, 0x69, 0x6e, 0x67, 0x2e, 0x58, 0x01, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x53, 0x22, 0x7a, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x29, 0x0a, 0x10, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77,
+	0x61, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61,
+	0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x22, 0xdb, 0x03, 0x0a, 0x04, 0x57, 0x69, 0x66, 0x69, 0x12, 0x71, 0x0a, 0x14, 0x77, 0x69, 0x66,
+	0x69, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x3f, 0x82, 0xb5, 0x18, 0x3b, 0x0a, 0x15, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x1a, 0x20, 0x54, 0x68, 0x65, 0x20, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x20, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65,
+	0x20, 0x77, 0x69, 0x66, 0x69, 0x2e, 0x28, 0x06, 0x52, 0x12, 0x77, 0x69, 0x66, 0x69, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3e, 0x0a, 0x09,
+	0x77, 0x69, 0x66, 0x69, 0x5f, 0x72, 0x73, 0x73, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42,
+	0x21, 0x82, 0xb5, 0x18, 0x1d, 0x0a, 0x04, 0x72, 0x73, 0x73, 0x69, 0x1a, 0x15, 0x54, 0x68, 0x65,
+	0x20, 0x52, 0x53, 0x53, 0x49, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x69, 0x66,
+	0x69, 0x2e, 0x52, 0x08, 0x77, 0x69, 0x66, 0x69, 0x52, 0x73, 0x73, 0x69, 0x12, 0x3a, 0x0a, 0x08,
+	0x77, 0x69, 0x66, 0x69, 0x5f, 0x73, 0x6e, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x1f,
+	0x82, 0xb5, 0x18, 0x1b, 0x0a, 0x03, 0x73, 0x6e, 0x72, 0x1a, 0x14, 0x54, 0x68, 0x65, 0x20, 0x53,
+	0x4e, 0x52, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x69, 0x66, 0x69, 0x2e, 0x52,
+	0x07, 0x77, 0x69, 0x66, 0x69, 0x53, 0x6e, 0x72, 0x12, 0x6f, 0x0a, 0x0e, 0x77, 0x69, 0x66, 0x69,
+	0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x42, 0x48, 0x82, 0xb5, 0x18, 0x44, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x1e, 0x57, 0x68, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x20, 0x74, 0x68, 0x65, 0x20, 0x77, 0x69, 0x66, 0x69, 0x20, 0x69, 0x73, 0x20, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x2e, 0x22, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x77, 0x69, 0x66, 0x69, 0x52, 0x0d, 0x77, 0x69, 0x66, 0x69,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x73, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x42, 0x57, 0x82, 0xb5, 0x18,
+	0x53, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x1a, 0x2b, 0x44, 0x6f, 0x65, 0x73, 0x20, 0x74, 0x68, 0x65, 0x20, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x20, 0x68, 0x61, 0x76, 0x65, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x65,
+	0x74, 0x20, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x2e, 0x22,
+	0x13, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x65, 0x74, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x2a, 0x98,
+	0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a,
 	0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x45, 0x52,
 	0x53, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x48, 0x5f, 0x54, 0x4f, 0x5f, 0x4a, 0x10,
-	0x02, 0x3a, 0x68, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x12,
-	0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0,
-	0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69, 0x6e,
-	0x73, 0x74, 0x6f, 0x6e, 0x64, 0x75, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52,
-	0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x42, 0x1f, 0x5a, 0x1d, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x31, 0x36, 0x37, 0x2f, 0x77,
-	0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x02, 0x12, 0x0c, 0x0a, 0x08, 0x4b, 0x57, 0x48, 0x5f, 0x54, 0x4f, 0x5f, 0x4a, 0x10, 0x03, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x56, 0x5f, 0x54, 0x4f, 0x5f, 0x56, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07,
+	0x4d, 0x53, 0x5f, 0x54, 0x4f, 0x5f, 0x53, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x45, 0x52,
+	0x43, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x4f, 0x5f, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x10, 0x06, 0x12,
+	0x0a, 0x0a, 0x06, 0x46, 0x5f, 0x54, 0x4f, 0x5f, 0x43, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x4b,
+	0x5f, 0x54, 0x4f, 0x5f, 0x43, 0x10, 0x08, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x43, 0x41, 0x4c, 0x45,
+	0x5f, 0x4f, 0x46, 0x46, 0x53, 0x45, 0x54, 0x10, 0x09, 0x3a, 0x68, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
+	0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x74, 0x6f, 0x6e, 0x64, 0x75, 0x72, 0x61,
+	0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68,
+	0x65, 0x75, 0x73, 0x3a, 0x64, 0x0a, 0x07, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1f,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0xd1, 0x86, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x77, 0x69,
+	0x6e, 0x73, 0x74, 0x6f, 0x6e, 0x64, 0x75, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x77, 0x61, 0x6c, 0x6c,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x52, 0x07, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x52, 0x31, 0x36, 0x37, 0x2f, 0x77, 0x61, 0x6c,
+	0x6c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1081,28 +1445,37 @@ func file_metrics_proto_rawDescGZIP() []byte {
 	return file_metrics_proto_rawDescData
 }
 
-var file_metrics_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_metrics_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_metrics_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_metrics_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_metrics_proto_goTypes = []interface{}{
-	(Conversion)(0),                   // 0: com.winstondurand.wallconnector.Conversion
-	(Metric_Type)(0),                  // 1: com.winstondurand.wallconnector.Metric.Type
-	(*Metric)(nil),                    // 2: com.winstondurand.wallconnector.Metric
-	(*Vitals)(nil),                    // 3: com.winstondurand.wallconnector.Vitals
-	(*Lifetime)(nil),                  // 4: com.winstondurand.wallconnector.Lifetime
-	(*Version)(nil),                   // 5: com.winstondurand.wallconnector.Version
-	(*Wifi)(nil),                      // 6: com.winstondurand.wallconnector.Wifi
-	(*descriptorpb.FieldOptions)(nil), // 7: google.protobuf.FieldOptions
+	(Conversion)(0),                     // 0: com.winstondurand.wallconnector.Conversion
+	(Metric_Type)(0),                    // 1: com.winstondurand.wallconnector.Metric.Type
+	(Metric_InvalidPolicy)(0),           // 2: com.winstondurand.wallconnector.Metric.InvalidPolicy
+	(Metric_Aggregate)(0),               // 3: com.winstondurand.wallconnector.Metric.Aggregate
+	(*Metric)(nil),                      // 4: com.winstondurand.wallconnector.Metric
+	(*Vitals)(nil),                      // 5: com.winstondurand.wallconnector.Vitals
+	(*Lifetime)(nil),                    // 6: com.winstondurand.wallconnector.Lifetime
+	(*Version)(nil),                     // 7: com.winstondurand.wallconnector.Version
+	(*Wifi)(nil),                        // 8: com.winstondurand.wallconnector.Wifi
+	(*Metric_Range)(nil),                // 9: com.winstondurand.wallconnector.Metric.Range
+	(*descriptorpb.FieldOptions)(nil),   // 10: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil), // 11: google.protobuf.MessageOptions
 }
 var file_metrics_proto_depIdxs = []int32{
-	1, // 0: com.winstondurand.wallconnector.Metric.type:type_name -> com.winstondurand.wallconnector.Metric.Type
-	0, // 1: com.winstondurand.wallconnector.Metric.conversion:type_name -> com.winstondurand.wallconnector.Conversion
-	7, // 2: com.winstondurand.wallconnector.prometheus:extendee -> google.protobuf.FieldOptions
-	2, // 3: com.winstondurand.wallconnector.prometheus:type_name -> com.winstondurand.wallconnector.Metric
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	3, // [3:4] is the sub-list for extension type_name
-	2, // [2:3] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1,  // 0: com.winstondurand.wallconnector.Metric.type:type_name -> com.winstondurand.wallconnector.Metric.Type
+	0,  // 1: com.winstondurand.wallconnector.Metric.conversion:type_name -> com.winstondurand.wallconnector.Conversion
+	9,  // 2: com.winstondurand.wallconnector.Metric.valid:type_name -> com.winstondurand.wallconnector.Metric.Range
+	2,  // 3: com.winstondurand.wallconnector.Metric.invalid:type_name -> com.winstondurand.wallconnector.Metric.InvalidPolicy
+	3,  // 4: com.winstondurand.wallconnector.Metric.aggregate:type_name -> com.winstondurand.wallconnector.Metric.Aggregate
+	10, // 5: com.winstondurand.wallconnector.prometheus:extendee -> google.protobuf.FieldOptions
+	11, // 6: com.winstondurand.wallconnector.derived:extendee -> google.protobuf.MessageOptions
+	4,  // 7: com.winstondurand.wallconnector.prometheus:type_name -> com.winstondurand.wallconnector.Metric
+	4,  // 8: com.winstondurand.wallconnector.derived:type_name -> com.winstondurand.wallconnector.Metric
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	7,  // [7:9] is the sub-list for extension type_name
+	5,  // [5:7] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_metrics_proto_init() }
@@ -1171,15 +1544,28 @@ func file_metrics_proto_init() {
 				return nil
 			}
 		}
+		file_metrics_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Metric_Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_metrics_proto_msgTypes[5].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_metrics_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   5,
-			NumExtensions: 1,
+			NumEnums:      4,
+			NumMessages:   6,
+			NumExtensions: 2,
 			NumServices:   0,
 		},
 		GoTypes:           file_metrics_proto_goTypes,
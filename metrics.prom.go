@@ -0,0 +1,460 @@
+// Code generated by protoc-gen-wallconnector-prom. DO NOT EDIT.
+// source: metrics.proto
+
+package wallconnector
+
+import (
+	proto "google.golang.org/protobuf/proto"
+)
+
+func (*Vitals) promFields() []promField[*Vitals] {
+	return promFieldsVitals
+}
+
+var promFieldsVitals = []promField[*Vitals]{
+	{
+		name: "contactor_closed",
+		metric: &Metric{
+			Name: "contactor_closed_status",
+			Help: "Whether the contactor is closed.",
+		},
+		value: func(x *Vitals) float64 { return promBool(x.GetContactorClosed()) },
+	},
+	{
+		name: "vehicle_connected",
+		metric: &Metric{
+			Name: "vehicle_connected_status",
+			Help: "Whether a vehicle is connected.",
+		},
+		value: func(x *Vitals) float64 { return promBool(x.GetVehicleConnected()) },
+	},
+	{
+		name: "session_s",
+		metric: &Metric{
+			Name: "session_seconds_total",
+			Type: Metric_COUNTER,
+			Help: "The duration of the current session.",
+		},
+		value: func(x *Vitals) float64 { return x.GetSessionS() },
+	},
+	{
+		name: "grid_v",
+		metric: &Metric{
+			Name:      "grid_voltage",
+			Help:      "The voltage of the grid.",
+			Deadband:  1,
+			Aggregate: []Metric_Aggregate{Metric_MINMAX},
+		},
+		value: func(x *Vitals) float64 { return x.GetGridV() },
+	},
+	{
+		name: "grid_hz",
+		metric: &Metric{
+			Name:       "grid_period_seconds",
+			Help:       "The frequency of the grid.",
+			Conversion: Conversion_INVERSE,
+			Valid: &Metric_Range{
+				Min: proto.Float64(40),
+				Max: proto.Float64(70),
+			},
+			Deadband: 0.05,
+		},
+		value: func(x *Vitals) float64 { return x.GetGridHz() },
+	},
+	{
+		name: "vehicle_current_a",
+		metric: &Metric{
+			Name:      "vehicle_current_amperes",
+			Help:      "The current being drawn by the vehicle.",
+			Deadband:  0.1,
+			Aggregate: []Metric_Aggregate{Metric_HISTOGRAM, Metric_MINMAX},
+		},
+		value: func(x *Vitals) float64 { return x.GetVehicleCurrentA() },
+	},
+	{
+		name: "currentA_a",
+		metric: &Metric{
+			Name:     "wall_amperes",
+			Help:     "The current being drawn at the wall.",
+			Labels:   []string{"phase:A"},
+			Deadband: 0.1,
+		},
+		value: func(x *Vitals) float64 { return x.GetCurrentAA() },
+	},
+	{
+		name: "currentB_a",
+		metric: &Metric{
+			Name:     "wall_amperes",
+			Help:     "The current being drawn at the wall.",
+			Labels:   []string{"phase:B"},
+			Deadband: 0.1,
+		},
+		value: func(x *Vitals) float64 { return x.GetCurrentBA() },
+	},
+	{
+		name: "currentC_a",
+		metric: &Metric{
+			Name:     "wall_amperes",
+			Help:     "The current being drawn at the wall.",
+			Labels:   []string{"phase:C"},
+			Deadband: 0.1,
+		},
+		value: func(x *Vitals) float64 { return x.GetCurrentCA() },
+	},
+	{
+		name: "currentN_a",
+		metric: &Metric{
+			Name:     "wall_amperes",
+			Help:     "The current being drawn at the wall.",
+			Labels:   []string{"phase:N"},
+			Deadband: 0.1,
+		},
+		value: func(x *Vitals) float64 { return x.GetCurrentNA() },
+	},
+	{
+		name: "voltageA_v",
+		metric: &Metric{
+			Name:      "wall_volts",
+			Help:      "The voltage at the wall.",
+			Labels:    []string{"phase:A"},
+			Deadband:  1,
+			Aggregate: []Metric_Aggregate{Metric_MINMAX},
+		},
+		value: func(x *Vitals) float64 { return x.GetVoltageAV() },
+	},
+	{
+		name: "voltageB_v",
+		metric: &Metric{
+			Name:      "wall_volts",
+			Help:      "The voltage at the wall.",
+			Labels:    []string{"phase:B"},
+			Deadband:  1,
+			Aggregate: []Metric_Aggregate{Metric_MINMAX},
+		},
+		value: func(x *Vitals) float64 { return x.GetVoltageBV() },
+	},
+	{
+		name: "voltageC_v",
+		metric: &Metric{
+			Name:      "wall_volts",
+			Help:      "The voltage at the wall.",
+			Labels:    []string{"phase:C"},
+			Deadband:  1,
+			Aggregate: []Metric_Aggregate{Metric_MINMAX},
+		},
+		value: func(x *Vitals) float64 { return x.GetVoltageCV() },
+	},
+	{
+		name: "relay_coil_v",
+		metric: &Metric{
+			Name: "relay_coil_volts",
+			Help: "The voltage at the relay coil.",
+		},
+		value: func(x *Vitals) float64 { return x.GetRelayCoilV() },
+	},
+	{
+		name: "pcba_temp_c",
+		metric: &Metric{
+			Name:     "temp_celsius",
+			Help:     "Temperature at various locations.",
+			Labels:   []string{"location:pcba"},
+			Deadband: 0.5,
+		},
+		value: func(x *Vitals) float64 { return x.GetPcbaTempC() },
+	},
+	{
+		name: "handle_temp_c",
+		metric: &Metric{
+			Name:     "temp_celsius",
+			Help:     "Temperature at various locations.",
+			Labels:   []string{"location:handle"},
+			Deadband: 0.5,
+		},
+		value: func(x *Vitals) float64 { return x.GetHandleTempC() },
+	},
+	{
+		name: "mcu_temp_c",
+		metric: &Metric{
+			Name:     "temp_celsius",
+			Help:     "Temperature at various locations.",
+			Labels:   []string{"location:mcu"},
+			Deadband: 0.5,
+		},
+		value: func(x *Vitals) float64 { return x.GetMcuTempC() },
+	},
+	{
+		name: "uptime_s",
+		metric: &Metric{
+			Name: "uptime_seconds_total",
+			Type: Metric_COUNTER,
+			Help: "The duration the device has been running.",
+		},
+		value: func(x *Vitals) float64 { return x.GetUptimeS() },
+	},
+	{
+		name: "input_thermopile_uv",
+		metric: &Metric{
+			Name:       "input_thermopile_volts",
+			Help:       "Input thermopile",
+			Conversion: Conversion_UV_TO_V,
+		},
+		value: func(x *Vitals) float64 { return x.GetInputThermopileUv() },
+	},
+	{
+		name: "prox_v",
+		metric: &Metric{
+			Name: "proximity_sensor_volts",
+			Help: "Proximity sensor voltage",
+		},
+		value: func(x *Vitals) float64 { return x.GetProxV() },
+	},
+	{
+		name: "pilot_high_v",
+		metric: &Metric{
+			Name: "pilot_high_volts",
+			Help: "Pilot high voltage",
+		},
+		value: func(x *Vitals) float64 { return x.GetPilotHighV() },
+	},
+	{
+		name: "pilot_low_v",
+		metric: &Metric{
+			Name: "pilot_low_volts",
+			Help: "Pilot low voltage",
+		},
+		value: func(x *Vitals) float64 { return x.GetPilotLowV() },
+	},
+	{
+		name: "session_energy_wh",
+		metric: &Metric{
+			Name:       "session_energy_joules_total",
+			Type:       Metric_COUNTER,
+			Help:       "The energy consumed during the current session.",
+			Conversion: Conversion_WH_TO_J,
+		},
+		value: func(x *Vitals) float64 { return x.GetSessionEnergyWh() },
+	},
+	{
+		name: "config_status",
+		metric: &Metric{
+			Name: "config_status",
+			Help: "The status of the configuration.",
+		},
+		int32: true,
+		value: func(x *Vitals) float64 { return float64(x.GetConfigStatus()) },
+	},
+	{
+		name: "evse_state",
+		metric: &Metric{
+			Name: "evse_state",
+			Help: "The state of the EVSE.",
+		},
+		int32: true,
+		value: func(x *Vitals) float64 { return float64(x.GetEvseState()) },
+	},
+	{
+		name: "voltageA_v * currentA_a",
+		metric: &Metric{
+			Name:   "wall_watts",
+			Help:   "The power being drawn at the wall.",
+			Labels: []string{"phase:A"},
+			Expr:   "voltageA_v * currentA_a",
+		},
+		value: func(x *Vitals) float64 { return x.GetVoltageAV() * x.GetCurrentAA() },
+	},
+	{
+		name: "voltageB_v * currentB_a",
+		metric: &Metric{
+			Name:   "wall_watts",
+			Help:   "The power being drawn at the wall.",
+			Labels: []string{"phase:B"},
+			Expr:   "voltageB_v * currentB_a",
+		},
+		value: func(x *Vitals) float64 { return x.GetVoltageBV() * x.GetCurrentBA() },
+	},
+	{
+		name: "voltageC_v * currentC_a",
+		metric: &Metric{
+			Name:   "wall_watts",
+			Help:   "The power being drawn at the wall.",
+			Labels: []string{"phase:C"},
+			Expr:   "voltageC_v * currentC_a",
+		},
+		value: func(x *Vitals) float64 { return x.GetVoltageCV() * x.GetCurrentCA() },
+	},
+	{
+		name: "grid_v * vehicle_current_a",
+		metric: &Metric{
+			Name: "vehicle_watts",
+			Help: "The power being drawn by the vehicle.",
+			Expr: "grid_v * vehicle_current_a",
+		},
+		value: func(x *Vitals) float64 { return x.GetGridV() * x.GetVehicleCurrentA() },
+	},
+}
+
+func (*Lifetime) promFields() []promField[*Lifetime] {
+	return promFieldsLifetime
+}
+
+var promFieldsLifetime = []promField[*Lifetime]{
+	{
+		name: "contactor_cycles",
+		metric: &Metric{
+			Name:      "contactor_cycles_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total number of times your Wall Connector has turned power on/off to your vehicle.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetContactorCycles()) },
+	},
+	{
+		name: "contactor_cycles_loaded",
+		metric: &Metric{
+			Name:      "contactor_cycles_loaded_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total number of times your Wall Connector has turned power on/off to your vehicle while the vehicle was charging.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetContactorCyclesLoaded()) },
+	},
+	{
+		name: "alert_count",
+		metric: &Metric{
+			Name:      "alert_count_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total number of alerts that have occurred on your Wall Connector.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetAlertCount()) },
+	},
+	{
+		name: "thermal_foldback_count",
+		metric: &Metric{
+			Name:      "thermal_foldback_count_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total number of times your Wall Connector has reduced the current to your vehicle due to high temperatures.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetThermalFoldbackCount()) },
+	},
+	{
+		name: "avg_startup_time",
+		metric: &Metric{
+			Name: "avg_startup_time_seconds",
+			Help: "Unknown.",
+		},
+		value: func(x *Lifetime) float64 { return x.GetAvgStartupTime() },
+	},
+	{
+		name: "charge_starts",
+		metric: &Metric{
+			Name:      "charge_starts_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total number of times your vehicle has started charging.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetChargeStarts()) },
+	},
+	{
+		name: "energy_wh",
+		metric: &Metric{
+			Name:       "energy_joules_total",
+			Type:       Metric_COUNTER,
+			Help:       "This is the total amount of energy your vehicle has consumed.",
+			Conversion: Conversion_WH_TO_J,
+			Monotonic:  true,
+		},
+		value: func(x *Lifetime) float64 { return float64(x.GetEnergyWh()) },
+	},
+	{
+		name: "connector_cycles",
+		metric: &Metric{
+			Name:      "connector_cycles_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total number of times your vehicle has been plugged in.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetConnectorCycles()) },
+	},
+	{
+		name: "uptime_s",
+		metric: &Metric{
+			Name:      "uptime_seconds_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total amount of time your Wall Connector has been powered on.",
+			Monotonic: true,
+		},
+		value: func(x *Lifetime) float64 { return float64(x.GetUptimeS()) },
+	},
+	{
+		name: "charge_time_s",
+		metric: &Metric{
+			Name:      "charge_time_seconds_total",
+			Type:      Metric_COUNTER,
+			Help:      "This is the total amount of time your vehicle has been charging.",
+			Monotonic: true,
+		},
+		int32: true,
+		value: func(x *Lifetime) float64 { return float64(x.GetChargeTimeS()) },
+	},
+}
+
+func (*Wifi) promFields() []promField[*Wifi] {
+	return promFieldsWifi
+}
+
+var promFieldsWifi = []promField[*Wifi]{
+	{
+		name: "wifi_signal_strength",
+		metric: &Metric{
+			Name:       "signal_strength_ratio",
+			Help:       "The signal strength of the wifi.",
+			Conversion: Conversion_PERCENT_TO_RATIO,
+		},
+		int32: true,
+		value: func(x *Wifi) float64 { return float64(x.GetWifiSignalStrength()) },
+	},
+	{
+		name: "wifi_rssi",
+		metric: &Metric{
+			Name: "rssi",
+			Help: "The RSSI of the wifi.",
+		},
+		int32: true,
+		value: func(x *Wifi) float64 { return float64(x.GetWifiRssi()) },
+	},
+	{
+		name: "wifi_snr",
+		metric: &Metric{
+			Name: "snr",
+			Help: "The SNR of the wifi.",
+		},
+		int32: true,
+		value: func(x *Wifi) float64 { return float64(x.GetWifiSnr()) },
+	},
+	{
+		name: "wifi_connected",
+		metric: &Metric{
+			Name:   "connection_status",
+			Help:   "Whether the wifi is connected.",
+			Labels: []string{"connection:wifi"},
+		},
+		value: func(x *Wifi) float64 { return promBool(x.GetWifiConnected()) },
+	},
+	{
+		name: "internet",
+		metric: &Metric{
+			Name:   "internet_status",
+			Help:   "Does the device have internet connectivity.",
+			Labels: []string{"connection:internet"},
+		},
+		value: func(x *Wifi) float64 { return promBool(x.GetInternet()) },
+	},
+}
@@ -3,10 +3,18 @@ package wallconnector
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestParsingMetrics(t *testing.T) {
@@ -14,7 +22,7 @@ func TestParsingMetrics(t *testing.T) {
 		return nil, nil
 	}
 
-	metrics := newMetricSet("vitals", dummy)
+	metrics := newMetricSet("vitals", dummy, nil, nil, time.Minute)
 
 	ch := make(chan *prometheus.Desc)
 	go func() {
@@ -27,5 +35,252 @@ func TestParsingMetrics(t *testing.T) {
 		fmt.Println(desc.String())
 		i++
 	}
-	assert.Equal(t, 25, i)
+	assert.Equal(t, 38, i)
+}
+
+func TestDerivedMetrics(t *testing.T) {
+	fetch := func(context.Context) (*Vitals, error) {
+		return &Vitals{GridV: 240, VehicleCurrentA: 32, VoltageAV: 120, CurrentAA: 10}, nil
+	}
+
+	metrics := newMetricSet("vitals", fetch, nil, nil, time.Minute).(*metricSet[*Vitals])
+	descs := make(map[string]*prometheus.Desc)
+	for _, metric := range metrics.metrics {
+		descs[metric.metric.GetName()] = metric.desc
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		metrics.Collect(context.Background(), ch)
+		close(ch)
+	}()
+
+	values := make(map[*prometheus.Desc][]float64)
+	for metric := range ch {
+		var m dto.Metric
+		assert.NoError(t, metric.Write(&m))
+		values[metric.Desc()] = append(values[metric.Desc()], m.GetGauge().GetValue())
+	}
+
+	// wall_watts shares a description across phases.
+	assert.Equal(t, []float64{1200, 0, 0}, values[descs["wall_watts"]])
+	assert.Equal(t, []float64{7680}, values[descs["vehicle_watts"]])
+}
+
+func TestConvertValue(t *testing.T) {
+	tests := []struct {
+		metric *Metric
+		in     float64
+		want   float64
+	}{
+		{&Metric{}, 42, 42},
+		{&Metric{Conversion: Conversion_INVERSE}, 50, 0.02},
+		{&Metric{Conversion: Conversion_WH_TO_J}, 2, 7200},
+		{&Metric{Conversion: Conversion_KWH_TO_J}, 1.5, 5.4e6},
+		{&Metric{Conversion: Conversion_UV_TO_V}, 1500, 0.0015},
+		{&Metric{Conversion: Conversion_MS_TO_S}, 250, 0.25},
+		{&Metric{Conversion: Conversion_PERCENT_TO_RATIO}, 74, 0.74},
+		{&Metric{Conversion: Conversion_F_TO_C}, 212, 100},
+		{&Metric{Conversion: Conversion_K_TO_C}, 273.15, 0},
+		{&Metric{Conversion: Conversion_SCALE_OFFSET, Scale: 2, Offset: -1}, 5, 9},
+		{&Metric{Conversion: Conversion_SCALE_OFFSET, Offset: 10}, 5, 15},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, tt.metric.ConvertValue(tt.in), 1e-9, tt.metric.GetConversion().String())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	inverse := &Metric{
+		Conversion: Conversion_INVERSE,
+		Valid:      &Metric_Range{Min: proto.Float64(40), Max: proto.Float64(70)},
+	}
+
+	val, valid, keep := inverse.Validate(50)
+	assert.Equal(t, 0.02, val)
+	assert.True(t, valid)
+	assert.True(t, keep)
+
+	_, valid, keep = inverse.Validate(0)
+	assert.False(t, valid)
+	assert.False(t, keep)
+
+	inverse.Invalid = Metric_NAN
+	val, valid, keep = inverse.Validate(0)
+	assert.True(t, math.IsNaN(val))
+	assert.False(t, valid)
+	assert.True(t, keep)
+
+	inverse.Invalid = Metric_CLAMP
+	val, valid, keep = inverse.Validate(80)
+	assert.InDelta(t, 1.0/70, val, 1e-9)
+	assert.False(t, valid)
+	assert.True(t, keep)
+
+	// Non-finite conversions are invalid even without a range.
+	_, valid, keep = (&Metric{Conversion: Conversion_INVERSE}).Validate(0)
+	assert.False(t, valid)
+	assert.False(t, keep)
+}
+
+func TestInvalidSamples(t *testing.T) {
+	fetch := func(context.Context) (*Vitals, error) {
+		return &Vitals{GridHz: 0}, nil
+	}
+
+	metrics := newMetricSet("vitals", fetch, nil, nil, time.Minute).(*metricSet[*Vitals])
+	ch := make(chan prometheus.Metric)
+	go func() {
+		metrics.Collect(context.Background(), ch)
+		close(ch)
+	}()
+	for range ch {
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.invalid.WithLabelValues("grid_period_seconds")))
+}
+
+func TestExpectedSerial(t *testing.T) {
+	serial := "PGT123"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case versionPath:
+			fmt.Fprintf(w, `{"serial_number": %q}`, serial)
+		case vitalsPath:
+			w.Write([]byte(`{"grid_v": 240}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	client, _ := NewClient(strings.TrimPrefix(srv.URL, "http://"))
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(NewCollector(client, WithExpectedSerial("PGT123")))
+
+	gather := func() (map[string]*dto.MetricFamily, error) {
+		mfs, err := reg.Gather()
+		byName := make(map[string]*dto.MetricFamily)
+		for _, mf := range mfs {
+			byName[mf.GetName()] = mf
+		}
+		return byName, err
+	}
+
+	mfs, err := gather()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, mfs["wallconnector_serial_mismatch"].GetMetric()[0].GetGauge().GetValue())
+	voltage := mfs["wallconnector_vitals_grid_voltage"].GetMetric()[0]
+	assert.Equal(t, 240.0, voltage.GetGauge().GetValue())
+	assert.Equal(t, "serial", voltage.GetLabel()[0].GetName())
+	assert.Equal(t, "PGT123", voltage.GetLabel()[0].GetValue())
+
+	serial = "OTHER-CHARGER"
+	mfs, err = gather()
+	assert.ErrorContains(t, err, `serial number "OTHER-CHARGER"`)
+	assert.Equal(t, 1.0, mfs["wallconnector_serial_mismatch"].GetMetric()[0].GetGauge().GetValue())
+	assert.NotContains(t, mfs, "wallconnector_vitals_grid_voltage")
+}
+
+func TestAggregates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client, _ := NewClient(strings.TrimPrefix(srv.URL, "http://"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	samples := make(chan VitalsSample)
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(NewCollector(client, WithVitalsSamples(ctx, samples)))
+
+	gather := func() map[string]*dto.MetricFamily {
+		mfs, err := reg.Gather()
+		assert.NoError(t, err)
+		byName := make(map[string]*dto.MetricFamily)
+		for _, mf := range mfs {
+			byName[mf.GetName()] = mf
+		}
+		return byName
+	}
+
+	// Nothing is aggregated before the first sample.
+	mfs := gather()
+	assert.NotContains(t, mfs, "wallconnector_vitals_vehicle_current_amperes_distribution")
+	assert.NotContains(t, mfs, "wallconnector_vitals_grid_voltage_min")
+
+	now := time.Now()
+	samples <- VitalsSample{Time: now, Vitals: &Vitals{GridV: 238, VehicleCurrentA: 16}}
+	samples <- VitalsSample{Time: now, Vitals: &Vitals{GridV: 243, VehicleCurrentA: 32}}
+	samples <- VitalsSample{Time: now, Vitals: &Vitals{GridV: 241, VehicleCurrentA: 24}}
+	// Unbuffered, so this returns once the previous sample was observed.
+	samples <- VitalsSample{Time: now, Err: fmt.Errorf("timeout")}
+
+	// Histograms are cumulative, so every gather sees every sample.
+	for i := 0; i < 2; i++ {
+		histogram := gather()["wallconnector_vitals_vehicle_current_amperes_distribution"].GetMetric()[0].GetHistogram()
+		assert.Equal(t, uint64(3), histogram.GetSampleCount())
+		assert.Equal(t, 72.0, histogram.GetSampleSum())
+	}
+
+	// Samples stop being read once ctx is done.
+	cancel()
+	assert.Eventually(t, func() bool {
+		select {
+		case samples <- VitalsSample{Time: now, Err: fmt.Errorf("timeout")}:
+			return false
+		case <-time.After(10 * time.Millisecond):
+			return true
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func TestMinMaxWindows(t *testing.T) {
+	metrics := newMetricSet("vitals", func(context.Context) (*Vitals, error) {
+		return nil, fmt.Errorf("offline")
+	}, nil, nil, time.Minute).(*metricSet[*Vitals])
+	start := time.Unix(600, 0)
+	now := start
+	metrics.now = func() time.Time { return now }
+
+	collect := func() map[string][]float64 {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			metrics.Collect(context.Background(), ch)
+			close(ch)
+		}()
+		values := make(map[string][]float64)
+		for m := range ch {
+			var d dto.Metric
+			assert.NoError(t, m.Write(&d))
+			name := m.Desc().String()
+			name = name[strings.Index(name, `"`)+1:]
+			name = name[:strings.Index(name, `"`)]
+			values[name] = append(values[name], d.GetGauge().GetValue())
+		}
+		return values
+	}
+
+	metrics.observe(start, &Vitals{GridV: 238, VoltageAV: 119})
+	metrics.observe(start.Add(10*time.Second), &Vitals{GridV: 243, VoltageAV: 121, VoltageBV: 120})
+	// The window isn't complete yet.
+	assert.NotContains(t, collect(), "wallconnector_vitals_grid_voltage_min")
+
+	metrics.observe(start.Add(time.Minute), &Vitals{GridV: 230})
+	now = start.Add(70 * time.Second)
+	// Every read sees the same completed window.
+	for i := 0; i < 2; i++ {
+		values := collect()
+		assert.Equal(t, []float64{238}, values["wallconnector_vitals_grid_voltage_min"])
+		assert.Equal(t, []float64{243}, values["wallconnector_vitals_grid_voltage_max"])
+		// Phases share a name, and are labelled.
+		assert.Len(t, values["wallconnector_vitals_wall_volts_max"], 3)
+	}
+
+	now = start.Add(2 * time.Minute)
+	assert.Equal(t, []float64{230}, collect()["wallconnector_vitals_grid_voltage_min"])
+	// A window without samples exports nothing.
+	now = start.Add(3 * time.Minute)
+	assert.NotContains(t, collect(), "wallconnector_vitals_grid_voltage_min")
 }
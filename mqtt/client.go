@@ -0,0 +1,261 @@
+// Package mqtt publishes wallconnector samples to an MQTT broker, along with
+// Home Assistant discovery configs so the charger appears automatically.
+//
+// It includes a minimal MQTT 3.1.1 client which only supports what is needed
+// to publish: QoS 0 messages, retained messages and keep alives.
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Control packet types.
+const (
+	packetConnect    = 1
+	packetConnAck    = 2
+	packetPublish    = 3
+	packetPingReq    = 12
+	packetPingResp   = 13
+	packetDisconnect = 14
+)
+
+type ClientConfig func(*clientOpts)
+
+type clientOpts struct {
+	// Client identifier sent to the broker.
+	ClientID string
+
+	// Credentials for the broker, if any.
+	Username string
+	Password string
+
+	// Interval to send keep alives at.
+	KeepAlive time.Duration
+}
+
+func WithClientID(id string) func(*clientOpts) {
+	return func(opts *clientOpts) {
+		opts.ClientID = id
+	}
+}
+
+func WithCredentials(username, password string) func(*clientOpts) {
+	return func(opts *clientOpts) {
+		opts.Username = username
+		opts.Password = password
+	}
+}
+
+func WithKeepAlive(d time.Duration) func(*clientOpts) {
+	return func(opts *clientOpts) {
+		opts.KeepAlive = d
+	}
+}
+
+// A Client is a connection to an MQTT broker.
+type Client struct {
+	conn net.Conn
+
+	mu sync.Mutex
+	w  *bufio.Writer
+
+	done chan struct{}
+	err  error
+}
+
+// Dial connects to the broker at addr (host:port).
+func Dial(ctx context.Context, addr string, opts ...ClientConfig) (*Client, error) {
+	o := &clientOpts{
+		ClientID:  "wallconnector",
+		KeepAlive: 60 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		conn: conn,
+		w:    bufio.NewWriter(conn),
+		done: make(chan struct{}),
+	}
+	if err := c.connect(ctx, o); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	go c.read()
+	go c.keepAlive(o.KeepAlive)
+	return c, nil
+}
+
+func (c *Client) connect(ctx context.Context, o *clientOpts) error {
+	var flags byte = 0x02 // clean session
+	payload := appendString(nil, o.ClientID)
+	if o.Username != "" {
+		flags |= 0x80
+		payload = appendString(payload, o.Username)
+	}
+	if o.Password != "" {
+		flags |= 0x40
+		payload = appendString(payload, o.Password)
+	}
+
+	body := appendString(nil, "MQTT")
+	body = append(body, 4, flags) // protocol level 3.1.1
+	body = binary.BigEndian.AppendUint16(body, uint16(o.KeepAlive/time.Second))
+	body = append(body, payload...)
+	if err := c.write(packetConnect<<4, body); err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetReadDeadline(deadline)
+		defer c.conn.SetReadDeadline(time.Time{})
+	}
+	typ, body, err := readPacket(c.conn)
+	if err != nil {
+		return err
+	}
+	if typ>>4 != packetConnAck || len(body) != 2 {
+		return fmt.Errorf("mqtt: unexpected packet %d, expected CONNACK", typ>>4)
+	}
+	if body[1] != 0 {
+		return fmt.Errorf("mqtt: connection refused, code %d", body[1])
+	}
+	return nil
+}
+
+// Publish publishes payload to topic with QoS 0.
+func (c *Client) Publish(topic string, payload []byte, retain bool) error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+	}
+
+	var header byte = packetPublish << 4
+	if retain {
+		header |= 0x01
+	}
+	body := appendString(nil, topic)
+	body = append(body, payload...)
+	return c.write(header, body)
+}
+
+// Done is closed when the connection to the broker is lost.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close disconnects from the broker.
+func (c *Client) Close() error {
+	err := c.write(packetDisconnect<<4, nil)
+	if cerr := c.conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (c *Client) write(header byte, body []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.w.WriteByte(header)
+	c.w.Write(appendLength(nil, len(body)))
+	c.w.Write(body)
+	return c.w.Flush()
+}
+
+// read discards incoming packets until the connection is closed.
+func (c *Client) read() {
+	r := bufio.NewReader(c.conn)
+	for {
+		if _, _, err := readPacket(r); err != nil {
+			c.err = fmt.Errorf("mqtt: connection lost: %w", err)
+			close(c.done)
+			return
+		}
+	}
+}
+
+func (c *Client) keepAlive(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.write(packetPingReq<<4, nil); err != nil {
+				c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// readPacket reads a control packet, returning its first header byte and body.
+func readPacket(r io.Reader) (byte, []byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, nil, err
+	}
+	header := b[0]
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("mqtt: malformed remaining length")
+		}
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, nil, err
+		}
+		length += int(b[0]&0x7f) * multiplier
+		multiplier *= 128
+		if b[0]&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// appendLength appends the variable length encoding of n.
+func appendLength(b []byte, n int) []byte {
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if n == 0 {
+			return b
+		}
+	}
+}
+
+// appendString appends a length prefixed UTF-8 string.
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
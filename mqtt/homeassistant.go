@@ -0,0 +1,133 @@
+package mqtt
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/R167/wallconnector"
+)
+
+// Home Assistant MQTT discovery, see
+// https://www.home-assistant.io/integrations/mqtt/#mqtt-discovery
+
+// A discovery config for a sensor or binary_sensor.
+type discoveryConfig struct {
+	Name              string `json:"name"`
+	UniqueID          string `json:"unique_id"`
+	StateTopic        string `json:"state_topic"`
+	DeviceClass       string `json:"device_class,omitempty"`
+	UnitOfMeasurement string `json:"unit_of_measurement,omitempty"`
+	StateClass        string `json:"state_class,omitempty"`
+	ValueTemplate     string `json:"value_template,omitempty"`
+	PayloadOn         string `json:"payload_on,omitempty"`
+	PayloadOff        string `json:"payload_off,omitempty"`
+	Device            device `json:"device"`
+}
+
+type device struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model,omitempty"`
+	SWVersion    string   `json:"sw_version,omitempty"`
+	SerialNumber string   `json:"serial_number,omitempty"`
+}
+
+// Units by the suffix of the metric name (without _total), along with the
+// device class and a template converting to a unit Home Assistant supports.
+var units = map[string]struct {
+	deviceClass, unit, template string
+}{
+	"volts":   {"voltage", "V", ""},
+	"voltage": {"voltage", "V", ""},
+	"amperes": {"current", "A", ""},
+	"watts":   {"power", "W", ""},
+	"celsius": {"temperature", "°C", ""},
+	"seconds": {"", "s", ""},
+	"joules":  {"energy", "kWh", "{{ value | float / 3600000 }}"},
+	"ratio":   {"", "%", "{{ value | float * 100 }}"},
+	"rssi":    {"signal_strength", "dBm", ""},
+	"snr":     {"", "dB", ""},
+	"hertz":   {"frequency", "Hz", ""},
+}
+
+// Metrics which are durations. Not every metric in seconds is one, e.g. the
+// period of the grid.
+var durations = map[string]bool{
+	"session_seconds_total":     true,
+	"uptime_seconds_total":      true,
+	"charge_time_seconds_total": true,
+	"avg_startup_time_seconds":  true,
+}
+
+// discovery returns the component and discovery config for a sample, derived
+// from its metric annotation.
+func discovery(s wallconnector.Sample, stateTopic, uniqueID string, dev device) (string, discoveryConfig) {
+	metric := s.Metric
+	config := discoveryConfig{
+		Name:       sensorName(s),
+		UniqueID:   uniqueID,
+		StateTopic: stateTopic,
+		Device:     dev,
+	}
+
+	name := metric.GetName()
+	if strings.HasSuffix(name, "_status") {
+		config.PayloadOn = "1"
+		config.PayloadOff = "0"
+		switch {
+		case s.Labels["connection"] != "":
+			config.DeviceClass = "connectivity"
+		case strings.Contains(name, "connected"):
+			config.DeviceClass = "plug"
+		case strings.Contains(name, "closed"):
+			config.DeviceClass = "power"
+		}
+		return "binary_sensor", config
+	}
+
+	config.StateClass = "measurement"
+	if metric.GetType() == wallconnector.Metric_COUNTER {
+		config.StateClass = "total_increasing"
+	}
+	parts := strings.Split(strings.TrimSuffix(name, "_total"), "_")
+	if u, ok := units[parts[len(parts)-1]]; ok {
+		config.DeviceClass = u.deviceClass
+		config.UnitOfMeasurement = u.unit
+		config.ValueTemplate = u.template
+	}
+	if durations[name] {
+		config.DeviceClass = "duration"
+	}
+	return "sensor", config
+}
+
+// sensorName returns a human readable name, e.g. "Vitals wall amperes (phase A)".
+func sensorName(s wallconnector.Sample) string {
+	name := strings.ToUpper(s.Set[:1]) + s.Set[1:] + " " + strings.ReplaceAll(s.Metric.GetName(), "_", " ")
+	if len(s.Labels) == 0 {
+		return name
+	}
+	labels := make([]string, 0, len(s.Labels))
+	for k, v := range s.Labels {
+		labels = append(labels, k+" "+v)
+	}
+	sort.Strings(labels)
+	return name + " (" + strings.Join(labels, ", ") + ")"
+}
+
+// objectID returns a unique identifier for a sample within a device, e.g.
+// vitals_wall_amperes_a.
+func objectID(s wallconnector.Sample) string {
+	keys := make([]string, 0, len(s.Labels))
+	for k := range s.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	id := s.Set + "_" + s.Metric.GetName()
+	for _, k := range keys {
+		id += "_" + strings.ToLower(s.Labels[k])
+	}
+	return id
+}
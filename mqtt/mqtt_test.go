@@ -0,0 +1,169 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+)
+
+type message struct {
+	topic   string
+	payload string
+	retain  bool
+}
+
+// broker is an in-process MQTT broker which records published messages.
+type broker struct {
+	ln       net.Listener
+	connect  chan []byte
+	messages chan message
+}
+
+func newBroker(t *testing.T) *broker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	b := &broker{ln: ln, connect: make(chan []byte, 1), messages: make(chan message, 100)}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			header, body, err := readPacket(conn)
+			if err != nil {
+				close(b.messages)
+				return
+			}
+			switch header >> 4 {
+			case packetConnect:
+				b.connect <- body
+				conn.Write([]byte{packetConnAck << 4, 2, 0, 0})
+			case packetPublish:
+				n := binary.BigEndian.Uint16(body)
+				b.messages <- message{
+					topic:   string(body[2 : 2+n]),
+					payload: string(body[2+n:]),
+					retain:  header&0x01 != 0,
+				}
+			case packetPingReq:
+				conn.Write([]byte{packetPingResp << 4, 0})
+			case packetDisconnect:
+				close(b.messages)
+				return
+			}
+		}
+	}()
+	return b
+}
+
+func TestClient(t *testing.T) {
+	b := newBroker(t)
+	c, err := Dial(context.Background(), b.ln.Addr().String(), WithClientID("test"), WithCredentials("user", "pass"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	connect := <-b.connect
+	assert.Equal(t, "\x00\x04MQTT\x04", string(connect[:7]))
+	assert.Equal(t, byte(0xc2), connect[7])
+	assert.Contains(t, string(connect), "test")
+	assert.Contains(t, string(connect), "user")
+
+	long := string(make([]byte, 300))
+	assert.NoError(t, c.Publish("a/b", []byte("hello"), false))
+	assert.NoError(t, c.Publish("a/c", []byte(long), true))
+	assert.NoError(t, c.Close())
+
+	assert.Equal(t, message{"a/b", "hello", false}, <-b.messages)
+	assert.Equal(t, message{"a/c", long, true}, <-b.messages)
+	_, ok := <-b.messages
+	assert.False(t, ok)
+}
+
+func TestPublisher(t *testing.T) {
+	b := newBroker(t)
+	c, err := Dial(context.Background(), b.ln.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	<-b.connect
+
+	version := &wallconnector.Version{SerialNumber: "A1234", PartNumber: "1529455-02-D", FirmwareVersion: "23.8.1"}
+	p := NewPublisher(c, version)
+	samples := []wallconnector.Sample{{
+		Set:    "vitals",
+		Metric: &wallconnector.Metric{Name: "wall_amperes"},
+		Labels: map[string]string{"phase": "A"},
+		Value:  16,
+	}}
+	assert.NoError(t, p.Publish(samples))
+	samples[0].Value = math.NaN()
+	assert.NoError(t, p.Publish(samples))
+	samples[0].Value = 15.5
+	assert.NoError(t, p.Publish(samples))
+	assert.NoError(t, c.Close())
+
+	msg := <-b.messages
+	assert.Equal(t, "homeassistant/sensor/wallconnector_A1234/vitals_wall_amperes_a/config", msg.topic)
+	assert.True(t, msg.retain)
+	var config map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(msg.payload), &config))
+	assert.Equal(t, "Vitals wall amperes (phase A)", config["name"])
+	assert.Equal(t, "wallconnector/A1234/vitals/wall_amperes_a", config["state_topic"])
+	assert.Equal(t, "current", config["device_class"])
+	assert.Equal(t, "A", config["unit_of_measurement"])
+	assert.Equal(t, "measurement", config["state_class"])
+	assert.Equal(t, "1529455-02-D", config["device"].(map[string]any)["model"])
+
+	assert.Equal(t, message{"wallconnector/A1234/vitals/wall_amperes_a", "16", true}, <-b.messages)
+	// Discovery is only published once, and NaN is skipped.
+	assert.Equal(t, message{"wallconnector/A1234/vitals/wall_amperes_a", "15.5", true}, <-b.messages)
+}
+
+func TestDiscovery(t *testing.T) {
+	energy := wallconnector.Sample{
+		Set:    "lifetime",
+		Metric: &wallconnector.Metric{Name: "energy_joules_total", Type: wallconnector.Metric_COUNTER},
+	}
+	component, config := discovery(energy, "", "", device{})
+	assert.Equal(t, "sensor", component)
+	assert.Equal(t, "energy", config.DeviceClass)
+	assert.Equal(t, "kWh", config.UnitOfMeasurement)
+	assert.Equal(t, "total_increasing", config.StateClass)
+
+	period := wallconnector.Sample{
+		Set:    "vitals",
+		Metric: &wallconnector.Metric{Name: "grid_period_seconds"},
+	}
+	_, config = discovery(period, "", "", device{})
+	assert.Empty(t, config.DeviceClass)
+	assert.Equal(t, "s", config.UnitOfMeasurement)
+
+	uptime := wallconnector.Sample{
+		Set:    "lifetime",
+		Metric: &wallconnector.Metric{Name: "uptime_seconds_total", Type: wallconnector.Metric_COUNTER},
+	}
+	_, config = discovery(uptime, "", "", device{})
+	assert.Equal(t, "duration", config.DeviceClass)
+
+	internet := wallconnector.Sample{
+		Set:    "wifi",
+		Metric: &wallconnector.Metric{Name: "internet_status"},
+		Labels: map[string]string{"connection": "internet"},
+	}
+	component, config = discovery(internet, "", "", device{})
+	assert.Equal(t, "binary_sensor", component)
+	assert.Equal(t, "connectivity", config.DeviceClass)
+	assert.Equal(t, "1", config.PayloadOn)
+}
@@ -0,0 +1,103 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/R167/wallconnector"
+)
+
+type PublisherConfig func(*publisherOpts)
+
+type publisherOpts struct {
+	// Prefix of the state topics. Defaults to wallconnector/<serial number>.
+	Prefix string
+
+	// Prefix Home Assistant listens for discovery configs on, or empty to
+	// disable discovery.
+	DiscoveryPrefix string
+}
+
+func WithPrefix(prefix string) func(*publisherOpts) {
+	return func(opts *publisherOpts) {
+		opts.Prefix = prefix
+	}
+}
+
+func WithDiscoveryPrefix(prefix string) func(*publisherOpts) {
+	return func(opts *publisherOpts) {
+		opts.DiscoveryPrefix = prefix
+	}
+}
+
+// A Publisher publishes the samples of a wall connector as retained messages
+// on <prefix>/<metric set>/<metric>, e.g. wallconnector/A1234/vitals/grid_voltage.
+type Publisher struct {
+	client *Client
+	opts   publisherOpts
+	node   string
+	device device
+
+	// Samples which have had their discovery config published.
+	announced map[string]bool
+}
+
+// NewPublisher creates a publisher for the wall connector identified by
+// version.
+func NewPublisher(client *Client, version *wallconnector.Version, opts ...PublisherConfig) *Publisher {
+	serial := version.GetSerialNumber()
+	o := publisherOpts{
+		Prefix:          "wallconnector/" + serial,
+		DiscoveryPrefix: "homeassistant",
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	node := "wallconnector_" + serial
+	return &Publisher{
+		client: client,
+		opts:   o,
+		node:   node,
+		device: device{
+			Identifiers:  []string{node},
+			Name:         "Wall Connector " + serial,
+			Manufacturer: "Tesla",
+			Model:        version.GetPartNumber(),
+			SWVersion:    version.GetFirmwareVersion(),
+			SerialNumber: serial,
+		},
+		announced: make(map[string]bool),
+	}
+}
+
+// Publish publishes samples, announcing any new ones to Home Assistant first.
+// NaN values, which Home Assistant rejects for numeric sensors, are skipped.
+func (p *Publisher) Publish(samples []wallconnector.Sample) error {
+	for _, s := range samples {
+		if math.IsNaN(s.Value) {
+			continue
+		}
+		id := objectID(s)
+		topic := p.opts.Prefix + "/" + s.Set + "/" + id[len(s.Set)+1:]
+
+		if p.opts.DiscoveryPrefix != "" && !p.announced[id] {
+			component, config := discovery(s, topic, p.node+"_"+id, p.device)
+			data, err := json.Marshal(config)
+			if err != nil {
+				return err
+			}
+			if err := p.client.Publish(p.opts.DiscoveryPrefix+"/"+component+"/"+p.node+"/"+id+"/config", data, true); err != nil {
+				return err
+			}
+			p.announced[id] = true
+		}
+
+		value := strconv.FormatFloat(s.Value, 'g', -1, 64)
+		if err := p.client.Publish(topic, []byte(value), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
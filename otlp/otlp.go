@@ -0,0 +1,236 @@
+// Package otlp pushes wallconnector metrics to an OpenTelemetry collector
+// using OTLP/HTTP with the JSON encoding.
+//
+// Gauges are mapped onto OTel gauges and counters onto cumulative monotonic
+// sums, using the same (prometheus) annotations as the prometheus collector.
+// Counters which reset, e.g. the session counters or firmware counters after a
+// reboot, start a new cumulative series from the previous export.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+const (
+	scopeName = "github.com/R167/wallconnector"
+
+	// AGGREGATION_TEMPORALITY_CUMULATIVE
+	cumulative = 2
+
+	// Timeout for exports with the default client, so a hung collector
+	// doesn't stop every later export.
+	exportTimeout = 10 * time.Second
+)
+
+type ExporterConfig func(*exporterOpts)
+
+type exporterOpts struct {
+	// http.Client to use for requests to the OTLP endpoint. Defaults to a
+	// client timing out after 10 seconds.
+	Client *http.Client
+
+	// Headers to send with every request, e.g. for authentication.
+	Headers map[string]string
+
+	// Attributes of the resource the metrics are reported for.
+	Resource map[string]string
+}
+
+func WithHTTPClient(c *http.Client) func(*exporterOpts) {
+	return func(opts *exporterOpts) {
+		opts.Client = c
+	}
+}
+
+func WithHeaders(h map[string]string) func(*exporterOpts) {
+	return func(opts *exporterOpts) {
+		opts.Headers = h
+	}
+}
+
+func WithResource(attrs map[string]string) func(*exporterOpts) {
+	return func(opts *exporterOpts) {
+		for k, v := range attrs {
+			opts.Resource[k] = v
+		}
+	}
+}
+
+// An Exporter pushes samples to an OTLP/HTTP endpoint.
+type Exporter struct {
+	endpoint string
+	opts     exporterOpts
+	start    time.Time
+
+	mu sync.Mutex
+	// Cumulative series by name and attributes.
+	series map[string]*series
+}
+
+// The state of a cumulative series.
+type series struct {
+	start time.Time
+	last  float64
+	time  time.Time
+}
+
+// NewExporter creates an exporter for the OTLP/HTTP metrics endpoint, e.g.
+// http://localhost:4318/v1/metrics.
+func NewExporter(endpoint string, opts ...ExporterConfig) *Exporter {
+	o := exporterOpts{
+		Client: &http.Client{Timeout: exportTimeout},
+		Resource: map[string]string{
+			"service.name": "wallconnector",
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Exporter{
+		endpoint: endpoint,
+		opts:     o,
+		start:    time.Now(),
+		series:   make(map[string]*series),
+	}
+}
+
+// Run polls client every interval and exports the samples until ctx is done.
+func (e *Exporter) Run(ctx context.Context, client *wallconnector.Client, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		samples, err := client.Samples(ctx)
+		if err != nil {
+			log.Printf("otlp: polling wallconnector: %v", err)
+		} else if err := e.Export(ctx, samples, time.Now()); err != nil {
+			log.Printf("otlp: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Export pushes samples observed at now.
+func (e *Exporter) Export(ctx context.Context, samples []wallconnector.Sample, now time.Time) error {
+	body, err := json.Marshal(e.request(samples, now))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("export failed: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// startTime returns the start time of the cumulative series key with value
+// observed at now. A decrease is a reset, so starts a new series from the
+// previous observation.
+func (e *Exporter) startTime(key string, value float64, now time.Time) time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	s, ok := e.series[key]
+	if !ok {
+		s = &series{start: e.start}
+		e.series[key] = s
+	} else if value < s.last {
+		s.start = s.time
+	}
+	s.last, s.time = value, now
+	return s.start
+}
+
+func (e *Exporter) request(samples []wallconnector.Sample, now time.Time) exportRequest {
+	ts := nanos(now)
+
+	// Samples sharing a name (e.g. per phase) are data points of one metric.
+	var metrics []*metric
+	byName := make(map[string]*metric)
+	for _, s := range samples {
+		// NaN can't be represented in JSON.
+		if math.IsNaN(s.Value) {
+			continue
+		}
+		name := s.FQName()
+		m, ok := byName[name]
+		if !ok {
+			m = &metric{Name: name, Description: s.Metric.GetHelp()}
+			if s.Metric.GetType() == wallconnector.Metric_COUNTER {
+				m.Sum = &sum{AggregationTemporality: cumulative, IsMonotonic: true}
+			} else {
+				m.Gauge = &gauge{}
+			}
+			byName[name] = m
+			metrics = append(metrics, m)
+		}
+
+		point := dataPoint{
+			Attributes:   attributes(s.Labels),
+			TimeUnixNano: ts,
+			AsDouble:     s.Value,
+		}
+		if m.Sum != nil {
+			key := name + fmt.Sprint(point.Attributes)
+			point.StartTimeUnixNano = nanos(e.startTime(key, s.Value, now))
+			m.Sum.DataPoints = append(m.Sum.DataPoints, point)
+		} else {
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, point)
+		}
+	}
+
+	return exportRequest{
+		ResourceMetrics: []resourceMetrics{{
+			Resource: resource{Attributes: attributes(e.opts.Resource)},
+			ScopeMetrics: []scopeMetrics{{
+				Scope:   scope{Name: scopeName},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+// nanos formats t as a fixed64, which the JSON encoding represents as a string.
+func nanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func attributes(m map[string]string) []keyValue {
+	attrs := make([]keyValue, 0, len(m))
+	for k, v := range m {
+		attrs = append(attrs, keyValue{Key: k, Value: anyValue{StringValue: v}})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
@@ -0,0 +1,84 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	samples := wallconnector.MessageSamples("vitals", &wallconnector.Vitals{
+		CurrentAA: 16,
+		CurrentBA: 15,
+		UptimeS:   120,
+	})
+	e := NewExporter(srv.URL, WithHeaders(map[string]string{"Authorization": "secret"}))
+	assert.NoError(t, e.Export(context.Background(), samples, time.Unix(10, 0)))
+
+	metrics := got["resourceMetrics"].([]any)[0].(map[string]any)["scopeMetrics"].([]any)[0].(map[string]any)["metrics"].([]any)
+	byName := make(map[string]map[string]any)
+	for _, m := range metrics {
+		m := m.(map[string]any)
+		byName[m["name"].(string)] = m
+	}
+
+	amps := byName["wallconnector_vitals_wall_amperes"]["gauge"].(map[string]any)["dataPoints"].([]any)
+	assert.Len(t, amps, 4)
+	assert.Equal(t, map[string]any{
+		"attributes":   []any{map[string]any{"key": "phase", "value": map[string]any{"stringValue": "A"}}},
+		"timeUnixNano": "10000000000",
+		"asDouble":     16.0,
+	}, amps[0])
+
+	uptime := byName["wallconnector_vitals_uptime_seconds_total"]["sum"].(map[string]any)
+	assert.Equal(t, true, uptime["isMonotonic"])
+	assert.Equal(t, 2.0, uptime["aggregationTemporality"])
+	assert.Equal(t, 120.0, uptime["dataPoints"].([]any)[0].(map[string]any)["asDouble"])
+}
+
+func TestExportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	err := NewExporter(srv.URL).Export(context.Background(), nil, time.Now())
+	assert.ErrorContains(t, err, "400 Bad Request")
+}
+
+func TestExportCounterReset(t *testing.T) {
+	e := NewExporter("")
+	e.start = time.Unix(0, 0)
+	starts := func(uptime float64, now int64) []string {
+		req := e.request(wallconnector.MessageSamples("vitals", &wallconnector.Vitals{UptimeS: uptime}), time.Unix(now, 0))
+		var starts []string
+		for _, m := range req.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+			if m.Name == "wallconnector_vitals_uptime_seconds_total" {
+				for _, p := range m.Sum.DataPoints {
+					starts = append(starts, p.StartTimeUnixNano)
+				}
+			}
+		}
+		return starts
+	}
+
+	assert.Equal(t, []string{"0"}, starts(120, 10))
+	assert.Equal(t, []string{"0"}, starts(130, 20))
+	// The charger rebooted, so the series restarts from the last export.
+	assert.Equal(t, []string{"20000000000"}, starts(5, 30))
+	assert.Equal(t, []string{"20000000000"}, starts(15, 40))
+}
@@ -0,0 +1,57 @@
+package remotewrite
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The remote write WriteRequest, encoded by hand to avoid depending on the
+// prometheus server module for prompb:
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label        { string name = 1; string value = 2; }
+//	message Sample       { double value = 1; int64 timestamp = 2; }
+
+type label struct {
+	name, value string
+}
+
+type timeSeries struct {
+	labels    []label
+	value     float64
+	timestamp int64
+}
+
+func encodeWriteRequest(series []timeSeries) []byte {
+	var b []byte
+	for _, s := range series {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeTimeSeries(s))
+	}
+	return b
+}
+
+func encodeTimeSeries(s timeSeries) []byte {
+	var b []byte
+	for _, l := range s.labels {
+		var lb []byte
+		lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.name)
+		lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.value)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, lb)
+	}
+
+	var sb []byte
+	sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+	sb = protowire.AppendFixed64(sb, math.Float64bits(s.value))
+	sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+	sb = protowire.AppendVarint(sb, uint64(s.timestamp))
+
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendBytes(b, sb)
+}
@@ -0,0 +1,214 @@
+// Package remotewrite pushes metrics from a prometheus registry using the
+// Prometheus remote write protocol, for sites where the exporter can't be
+// scraped. See https://prometheus.io/docs/concepts/remote_write_spec/
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Timeout for pushes with the default client, so a hung endpoint doesn't stop
+// every later push.
+const pushTimeout = 10 * time.Second
+
+type PusherConfig func(*pusherOpts)
+
+type pusherOpts struct {
+	// http.Client to use for requests to the remote write endpoint. Defaults
+	// to a client timing out after 10 seconds.
+	Client *http.Client
+
+	// Labels added to every series, e.g. job and instance.
+	Labels map[string]string
+
+	// Credentials for basic auth, if set.
+	Username string
+	Password string
+
+	// Bearer token, if set.
+	BearerToken string
+}
+
+func WithHTTPClient(c *http.Client) func(*pusherOpts) {
+	return func(opts *pusherOpts) {
+		opts.Client = c
+	}
+}
+
+func WithLabels(labels map[string]string) func(*pusherOpts) {
+	return func(opts *pusherOpts) {
+		opts.Labels = labels
+	}
+}
+
+func WithBasicAuth(username, password string) func(*pusherOpts) {
+	return func(opts *pusherOpts) {
+		opts.Username = username
+		opts.Password = password
+	}
+}
+
+func WithBearerToken(token string) func(*pusherOpts) {
+	return func(opts *pusherOpts) {
+		opts.BearerToken = token
+	}
+}
+
+// A Pusher gathers metrics and sends them to a remote write endpoint.
+type Pusher struct {
+	url      string
+	gatherer prometheus.Gatherer
+	opts     pusherOpts
+}
+
+// NewPusher creates a pusher sending the metrics of gatherer to url.
+func NewPusher(url string, gatherer prometheus.Gatherer, opts ...PusherConfig) *Pusher {
+	o := pusherOpts{
+		Client: &http.Client{Timeout: pushTimeout},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Pusher{
+		url:      url,
+		gatherer: gatherer,
+		opts:     o,
+	}
+}
+
+// Run pushes every interval until ctx is done.
+func (p *Pusher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Push(ctx); err != nil {
+			log.Printf("remote write: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Push gathers and sends the metrics once.
+func (p *Pusher) Push(ctx context.Context) error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		// Gather returns as many metrics as possible, so push them anyway.
+		log.Printf("remote write: gathering metrics: %v", err)
+	}
+	series := p.timeSeries(families, time.Now())
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if p.opts.Username != "" {
+		req.SetBasicAuth(p.opts.Username, p.opts.Password)
+	}
+	if p.opts.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.opts.BearerToken)
+	}
+
+	resp, err := p.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("push failed: %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// timeSeries flattens metric families into series, expanding summaries and
+// histograms into their component series the same way the text exposition
+// format does.
+func (p *Pusher) timeSeries(families []*dto.MetricFamily, now time.Time) []timeSeries {
+	ts := now.UnixMilli()
+	var series []timeSeries
+	add := func(name string, m *dto.Metric, value float64, extra ...string) {
+		labels := []label{{"__name__", name}}
+		seen := map[string]bool{"__name__": true}
+		for _, l := range m.GetLabel() {
+			labels = append(labels, label{l.GetName(), l.GetValue()})
+			seen[l.GetName()] = true
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			labels = append(labels, label{extra[i], extra[i+1]})
+			seen[extra[i]] = true
+		}
+		// Labels of the series win, like honor_labels when scraping, as
+		// remote write rejects duplicate label names.
+		for k, v := range p.opts.Labels {
+			if !seen[k] {
+				labels = append(labels, label{k, v})
+			}
+		}
+		sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+
+		t := ts
+		if m.TimestampMs != nil {
+			t = m.GetTimestampMs()
+		}
+		series = append(series, timeSeries{labels: labels, value: value, timestamp: t})
+	}
+
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.GetMetric() {
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m, m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, m, m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, m, q.GetValue(), "quantile", formatFloat(q.GetQuantile()))
+				}
+				add(name+"_sum", m, s.GetSampleSum())
+				add(name+"_count", m, float64(s.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					add(name+"_bucket", m, float64(b.GetCumulativeCount()), "le", formatFloat(b.GetUpperBound()))
+				}
+				add(name+"_bucket", m, float64(h.GetSampleCount()), "le", "+Inf")
+				add(name+"_sum", m, h.GetSampleSum())
+				add(name+"_count", m, float64(h.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
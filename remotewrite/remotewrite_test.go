@@ -0,0 +1,116 @@
+package remotewrite
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteRequest decodes series as "name{labels}" to their value.
+func decodeWriteRequest(t *testing.T, b []byte) map[string]float64 {
+	series := make(map[string]float64)
+	for len(b) > 0 {
+		_, _, n := protowire.ConsumeTag(b)
+		ts, m := protowire.ConsumeBytes(b[n:])
+		b = b[n+m:]
+
+		var name, labels string
+		var value float64
+		for len(ts) > 0 {
+			num, _, n := protowire.ConsumeTag(ts)
+			field, m := protowire.ConsumeBytes(ts[n:])
+			ts = ts[n+m:]
+			if num == 1 {
+				var kv [2]string
+				for i := range kv {
+					_, _, n := protowire.ConsumeTag(field)
+					v, m := protowire.ConsumeString(field[n:])
+					field = field[n+m:]
+					kv[i] = v
+				}
+				if kv[0] == "__name__" {
+					name = kv[1]
+				} else {
+					labels += kv[0] + "=" + kv[1] + ","
+				}
+			} else {
+				_, _, n := protowire.ConsumeTag(field)
+				bits, _ := protowire.ConsumeFixed64(field[n:])
+				value = math.Float64frombits(bits)
+			}
+		}
+		series[name+"{"+labels+"}"] = value
+	}
+	return series
+}
+
+func TestPush(t *testing.T) {
+	var series map[string]float64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "0.1.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+
+		compressed, _ := io.ReadAll(r.Body)
+		body, err := snappy.Decode(nil, compressed)
+		assert.NoError(t, err)
+		series = decodeWriteRequest(t, body)
+	}))
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total", Help: "Test."}, []string{"phase"})
+	counter.WithLabelValues("A").Add(3)
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{Name: "test_seconds", Help: "Test.", Objectives: map[float64]float64{0.5: 0.05}})
+	summary.Observe(2)
+	reg.MustRegister(counter, summary)
+
+	p := NewPusher(srv.URL, reg,
+		WithBasicAuth("user", "pass"),
+		WithLabels(map[string]string{"job": "wallconnector"}),
+	)
+	assert.NoError(t, p.Push(context.Background()))
+	assert.Equal(t, map[string]float64{
+		"test_total{job=wallconnector,phase=A,}":        3,
+		"test_seconds{job=wallconnector,quantile=0.5,}": 2,
+		"test_seconds_sum{job=wallconnector,}":          2,
+		"test_seconds_count{job=wallconnector,}":        1,
+	}, series)
+}
+
+func TestPushBearerToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	err := NewPusher(srv.URL, prometheus.NewRegistry(), WithBearerToken("token")).Push(context.Background())
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestTimeSeriesLabelsOfSeriesWin(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test", Help: "Test.", ConstLabels: prometheus.Labels{"instance": "garage"}})
+	reg.MustRegister(gauge)
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+
+	p := NewPusher("", reg, WithLabels(map[string]string{"job": "wallconnector", "instance": "localhost:9090"}))
+	series := p.timeSeries(families, time.Now())
+	if assert.Len(t, series, 1) {
+		assert.Equal(t, []label{{"__name__", "test"}, {"instance", "garage"}, {"job", "wallconnector"}}, series[0].labels)
+	}
+}
@@ -0,0 +1,141 @@
+package wallconnector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A resolver finds the address of a wallconnector by its serial number, and
+// checks the address still belongs to it, since DHCP may hand it to another
+// device.
+type resolver struct {
+	serial   string
+	subnet   string
+	opts     []DiscoverConfig
+	interval time.Duration
+
+	mu       sync.Mutex
+	addr     string
+	verified time.Time
+	scanned  time.Time
+	// The refresh in progress, if any. Refreshes scan the subnet, so are
+	// done without holding mu and shared by concurrent requests.
+	refreshing *refresh
+}
+
+type refresh struct {
+	done chan struct{}
+	addr string
+	err  error
+}
+
+// resolve returns the address of the wallconnector, verifying its serial
+// number if it hasn't been within the verify interval, and scanning the
+// subnet for it if it has moved.
+func (r *resolver) resolve(ctx context.Context, c *Client) (string, error) {
+	r.mu.Lock()
+	if r.addr != "" && time.Since(r.verified) < r.interval {
+		addr := r.addr
+		r.mu.Unlock()
+		return addr, nil
+	}
+	f := r.refreshing
+	if f == nil {
+		f = &refresh{done: make(chan struct{})}
+		r.refreshing = f
+		addr, scanned := r.addr, r.scanned
+		r.mu.Unlock()
+		go func() {
+			// Not canceled with ctx, as other requests may share it.
+			// Probes time out on their own.
+			f.addr, f.err = r.refresh(context.WithoutCancel(ctx), c, addr, scanned)
+			r.mu.Lock()
+			r.refreshing = nil
+			r.mu.Unlock()
+			close(f.done)
+		}()
+	} else {
+		r.mu.Unlock()
+	}
+
+	select {
+	case <-f.done:
+		return f.addr, f.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// refresh verifies addr, the last known address, and scans the subnet if the
+// wallconnector isn't there, publishing the result.
+func (r *resolver) refresh(ctx context.Context, c *Client, addr string, scanned time.Time) (string, error) {
+	now := time.Now()
+	var verifyErr error
+	if addr != "" {
+		version, err := get[Version](ctx, c, addr, versionPath)
+		switch {
+		case err != nil:
+			// The wallconnector may be offline, so keep trying the address
+			// unless it is found elsewhere.
+			verifyErr = err
+		case version.GetSerialNumber() == r.serial:
+			r.publish(addr, now, time.Time{})
+			return addr, nil
+		default:
+			// The address now belongs to another device.
+			addr = ""
+			r.publish("", time.Time{}, time.Time{})
+		}
+	}
+
+	// Avoid scanning the subnet on every request while the wallconnector is
+	// offline.
+	if !scanned.IsZero() && now.Sub(scanned) < r.interval {
+		if verifyErr != nil {
+			return "", verifyErr
+		}
+		return "", fmt.Errorf("wallconnector: serial number %s not found in %s", r.serial, r.subnet)
+	}
+	r.publish(addr, time.Time{}, now)
+	found, err := Discover(ctx, r.subnet, r.opts...)
+	if err != nil {
+		return "", err
+	}
+	for _, d := range found {
+		if d.Version.GetSerialNumber() == r.serial {
+			r.publish(d.Addr, now, time.Time{})
+			return d.Addr, nil
+		}
+	}
+	return "", fmt.Errorf("wallconnector: serial number %s not found in %s", r.serial, r.subnet)
+}
+
+// publish sets the address, and the times it was verified and the subnet
+// scanned unless they are zero.
+func (r *resolver) publish(addr string, verified, scanned time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addr = addr
+	if !verified.IsZero() {
+		r.verified = verified
+	}
+	if !scanned.IsZero() {
+		r.scanned = scanned
+	}
+}
+
+// invalidate forces the address to be verified on the next request.
+func (r *resolver) invalidate() {
+	r.mu.Lock()
+	r.verified = time.Time{}
+	r.mu.Unlock()
+}
+
+// current returns the last resolved address, if any.
+func (r *resolver) current() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addr
+}
@@ -0,0 +1,138 @@
+package wallconnector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCharger serves a wallconnector with the serial number returned by
+// serial on ip:port, skipping the test if ip can't be listened on.
+func fakeCharger(t *testing.T, ip string, port int, serial func() string) int {
+	l, err := net.Listen("tcp", net.JoinHostPort(ip, fmt.Sprint(port)))
+	if err != nil {
+		t.Skipf("listening on %s: %v", ip, err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case versionPath:
+			fmt.Fprintf(w, `{"part_number": "1529455-02-D", "serial_number": %q}`, serial())
+		case vitalsPath:
+			fmt.Fprintf(w, `{"grid_v": %d}`, len(serial()))
+		}
+	}))
+	srv.Listener.Close()
+	srv.Listener = l
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestResolveSerialNumber(t *testing.T) {
+	// The charger moves from 127.0.0.2 to 127.0.0.1 after the first request.
+	var moved atomic.Bool
+	port := fakeCharger(t, "127.0.0.1", 0, func() string {
+		if moved.Load() {
+			return "PGT123"
+		}
+		return "OTHER-CHARGER"
+	})
+	fakeCharger(t, "127.0.0.2", port, func() string {
+		if moved.Load() {
+			return "OTHER-CHARGER"
+		}
+		return "PGT123"
+	})
+
+	client, _ := NewClient("", WithSerialNumber("PGT123", "127.0.0.0/30", WithPort(port)), WithVerifyInterval(0))
+	assert.Equal(t, "", client.Addr())
+
+	vitals, err := client.Vitals(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 6.0, vitals.GetGridV())
+	}
+	assert.Equal(t, fmt.Sprintf("127.0.0.2:%d", port), client.Addr())
+
+	moved.Store(true)
+	vitals, err = client.Vitals(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 6.0, vitals.GetGridV())
+	}
+	assert.Equal(t, fmt.Sprintf("127.0.0.1:%d", port), client.Addr())
+}
+
+func TestResolveSerialNumberNotFound(t *testing.T) {
+	port := fakeCharger(t, "127.0.0.1", 0, func() string { return "OTHER-CHARGER" })
+
+	client, _ := NewClient(fmt.Sprintf("127.0.0.1:%d", port), WithSerialNumber("PGT123", "127.0.0.1/32", WithPort(port)))
+	_, err := client.Vitals(context.Background())
+	assert.ErrorContains(t, err, "serial number PGT123 not found")
+	assert.Equal(t, "", client.Addr())
+}
+
+// fakeSubnet is a transport serving a wallconnector with serial PGT123 at
+// host, which blocks probes until release is closed.
+type fakeSubnet struct {
+	host    string
+	release chan struct{}
+	probes  atomic.Int32
+}
+
+func (f *fakeSubnet) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == versionPath {
+		f.probes.Add(1)
+		<-f.release
+	}
+	if req.URL.Host != f.host {
+		return nil, fmt.Errorf("connection refused")
+	}
+	rec := httptest.NewRecorder()
+	switch req.URL.Path {
+	case versionPath:
+		rec.WriteString(`{"part_number": "1529455-02-D", "serial_number": "PGT123"}`)
+	case vitalsPath:
+		rec.WriteString(`{"grid_v": 240}`)
+	}
+	return rec.Result(), nil
+}
+
+func TestResolveConcurrent(t *testing.T) {
+	subnet := &fakeSubnet{host: "10.0.0.5", release: make(chan struct{})}
+	client, _ := NewClient("", WithTransport(subnet), WithSerialNumber("PGT123", "10.0.0.0/29"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vitals, err := client.Vitals(context.Background())
+			if assert.NoError(t, err) {
+				assert.Equal(t, 240.0, vitals.GetGridV())
+			}
+		}()
+	}
+	// The scan doesn't hold the lock, so the address can be read while it
+	// runs.
+	assert.Eventually(t, func() bool { return subnet.probes.Load() > 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, "", client.Addr())
+
+	// A request which gives up doesn't wait for the scan.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Version(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(subnet.release)
+	wg.Wait()
+	assert.Equal(t, "10.0.0.5", client.Addr())
+	// The requests shared one scan of the 6 hosts.
+	assert.Equal(t, int32(6), subnet.probes.Load())
+}
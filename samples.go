@@ -0,0 +1,82 @@
+package wallconnector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Sample is a single annotated value read from the wallconnector, validated
+// and converted the same way as it is exported by the prometheus collector.
+//
+// Samples are stateless, so monotonic counters are reported as-is.
+type Sample struct {
+	// The metric set the sample was read from, e.g. vitals.
+	Set    string
+	Metric *Metric
+	Labels map[string]string
+	Value  float64
+}
+
+// FQName returns the fully qualified prometheus name of the sample, e.g.
+// wallconnector_vitals_grid_voltage.
+func (s Sample) FQName() string {
+	return prometheus.BuildFQName("wallconnector", s.Set, s.Metric.GetName())
+}
+
+// LabelMap returns the labels of the metric as a map.
+func (m *Metric) LabelMap() map[string]string {
+	labels := make(map[string]string, len(m.GetLabels()))
+	for _, label := range m.GetLabels() {
+		key, value, _ := strings.Cut(label, ":")
+		labels[key] = value
+	}
+	return labels
+}
+
+// MessageSamples returns the samples for all annotated fields and derived
+// metrics of v. Invalid samples which the policy of their metric drops are
+// omitted.
+func MessageSamples[T proto.Message](set string, v T) []Sample {
+	var samples []Sample
+	for _, field := range messageFields[T]() {
+		if field.value == nil {
+			continue
+		}
+		val, _, keep := field.metric.Validate(field.value(v))
+		if !keep {
+			continue
+		}
+		samples = append(samples, Sample{
+			Set:    set,
+			Metric: field.metric,
+			Labels: field.metric.LabelMap(),
+			Value:  val,
+		})
+	}
+	return samples
+}
+
+// Samples polls the vitals, lifetime and wifi status of the wallconnector and
+// returns their samples.
+func (c *Client) Samples(ctx context.Context) ([]Sample, error) {
+	vitals, err := c.Vitals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lifetime, err := c.Lifetime(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wifi, err := c.Wifi(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := MessageSamples("vitals", vitals)
+	samples = append(samples, MessageSamples("lifetime", lifetime)...)
+	samples = append(samples, MessageSamples("wifi", wifi)...)
+	return samples, nil
+}
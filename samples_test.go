@@ -0,0 +1,23 @@
+package wallconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageSamples(t *testing.T) {
+	samples := MessageSamples("vitals", &Vitals{GridV: 240, GridHz: 0, VehicleCurrentA: 32})
+
+	byName := make(map[string][]Sample)
+	for _, s := range samples {
+		byName[s.FQName()] = append(byName[s.FQName()], s)
+	}
+
+	assert.Equal(t, 240.0, byName["wallconnector_vitals_grid_voltage"][0].Value)
+	assert.Equal(t, 7680.0, byName["wallconnector_vitals_vehicle_watts"][0].Value)
+	assert.Len(t, byName["wallconnector_vitals_wall_amperes"], 4)
+	assert.Equal(t, map[string]string{"phase": "N"}, byName["wallconnector_vitals_wall_amperes"][3].Labels)
+	// Dropped by the valid range of grid_hz.
+	assert.NotContains(t, byName, "wallconnector_vitals_grid_period_seconds")
+}
@@ -0,0 +1,85 @@
+package site
+
+import (
+	"context"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	current    *prometheus.Desc
+	headroom   *prometheus.Desc
+	budget     *prometheus.Desc
+	overBudget *prometheus.Desc
+	up         *prometheus.Desc
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		current: prometheus.NewDesc(
+			"wallconnector_site_current_amperes",
+			"Current drawn by all chargers on a phase.",
+			[]string{"phase"}, nil,
+		),
+		headroom: prometheus.NewDesc(
+			"wallconnector_site_headroom_amperes",
+			"Current left on a phase before the chargers exceed the budget.",
+			[]string{"phase"}, nil,
+		),
+		budget: prometheus.NewDesc(
+			"wallconnector_site_budget_amperes",
+			"Current the chargers may draw on each phase.",
+			nil, nil,
+		),
+		overBudget: prometheus.NewDesc(
+			"wallconnector_site_over_budget",
+			"Whether the chargers draw more than the budget on any phase.",
+			nil, nil,
+		),
+		up: prometheus.NewDesc(
+			"wallconnector_site_charger_up",
+			"Whether the vitals of a charger could be read.",
+			[]string{"charger"}, nil,
+		),
+	}
+}
+
+func (b *Budget) Describe(ch chan<- *prometheus.Desc) {
+	ch <- b.metrics.current
+	ch <- b.metrics.headroom
+	ch <- b.metrics.budget
+	ch <- b.metrics.overBudget
+	ch <- b.metrics.up
+}
+
+// Collect reads every charger and exports the load of the site. The totals
+// are exported even when some chargers can't be read, in which case they
+// under count the load.
+func (b *Budget) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.opts.Timeout)
+	defer cancel()
+	s := b.Read(ctx)
+
+	for _, r := range s.Chargers {
+		up := 1.0
+		if r.Err != nil {
+			log.Print(r.Err)
+			up = 0
+		}
+		ch <- prometheus.MustNewConstMetric(b.metrics.up, prometheus.GaugeValue, up, r.Name)
+	}
+	for i, phase := range Phases {
+		ch <- prometheus.MustNewConstMetric(b.metrics.current, prometheus.GaugeValue, s.CurrentA[i], phase)
+		ch <- prometheus.MustNewConstMetric(b.metrics.headroom, prometheus.GaugeValue, s.HeadroomA[i], phase)
+	}
+	ch <- prometheus.MustNewConstMetric(b.metrics.budget, prometheus.GaugeValue, s.BudgetA)
+	overBudget := 0.0
+	if s.OverBudget {
+		overBudget = 1
+	}
+	ch <- prometheus.MustNewConstMetric(b.metrics.overBudget, prometheus.GaugeValue, overBudget)
+}
+
+// Ensure we implement the [prometheus.Collector] interface
+var _ prometheus.Collector = (*Budget)(nil)
@@ -0,0 +1,158 @@
+// Package site aggregates the load of several wall connectors sharing an
+// electrical service, as a first step toward managing the load of the site.
+//
+// A [Budget] sums the current of each phase across chargers and compares it
+// to the limit of the breaker or service feeding them.
+package site
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/R167/wallconnector/control"
+)
+
+// Phases of the service.
+var Phases = []string{"A", "B", "C"}
+
+// A Charger is a wall connector on the site.
+type Charger struct {
+	Name   string
+	Vitals control.VitalsSource
+}
+
+type Config func(*budgetOpts)
+
+type budgetOpts struct {
+	// Fraction of the limit which may be used.
+	Derate float64
+
+	// Timeout for reading the vitals of all chargers.
+	Timeout time.Duration
+}
+
+// WithDerate sets the fraction of the limit which may be used. Defaults to
+// 0.8, as EV charging is a continuous load which the NEC limits to 80% of the
+// rating of a breaker.
+func WithDerate(fraction float64) func(*budgetOpts) {
+	return func(opts *budgetOpts) {
+		opts.Derate = fraction
+	}
+}
+
+// WithTimeout sets the timeout for reading the vitals of all chargers when
+// collecting metrics. Defaults to 5 seconds.
+func WithTimeout(t time.Duration) func(*budgetOpts) {
+	return func(opts *budgetOpts) {
+		opts.Timeout = t
+	}
+}
+
+// A Reading is the current drawn by a charger on each phase.
+type Reading struct {
+	Name string
+	// Indexed like Phases.
+	CurrentA []float64
+	Err      error
+}
+
+// A Snapshot is the load of the site at a point in time.
+type Snapshot struct {
+	Time     time.Time
+	Chargers []Reading
+
+	// Total current and the headroom below the budget of each phase, indexed
+	// like Phases. Chargers which couldn't be read are not included.
+	CurrentA  []float64
+	HeadroomA []float64
+	// The current which may be drawn on each phase.
+	BudgetA float64
+	// Whether any phase is over budget.
+	OverBudget bool
+}
+
+// Complete reports whether every charger was read.
+func (s Snapshot) Complete() bool {
+	for _, r := range s.Chargers {
+		if r.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// A Budget is the power budget of chargers sharing a service.
+type Budget struct {
+	chargers []Charger
+	// Rating of the breaker or service in amps per phase.
+	limit float64
+	opts  *budgetOpts
+
+	metrics *metrics
+}
+
+// NewBudget creates the budget of chargers fed by a breaker or service rated
+// limit amps per phase.
+func NewBudget(chargers []Charger, limit float64, opts ...Config) *Budget {
+	o := &budgetOpts{
+		Derate:  0.8,
+		Timeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Budget{
+		chargers: chargers,
+		limit:    limit,
+		opts:     o,
+		metrics:  newMetrics(),
+	}
+}
+
+// Read reads the vitals of every charger concurrently and sums their current.
+// Chargers which can't be read have their error in the snapshot.
+func (b *Budget) Read(ctx context.Context) Snapshot {
+	s := Snapshot{
+		Time:      time.Now(),
+		Chargers:  make([]Reading, len(b.chargers)),
+		CurrentA:  make([]float64, len(Phases)),
+		HeadroomA: make([]float64, len(Phases)),
+		BudgetA:   b.limit * b.opts.Derate,
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range b.chargers {
+		wg.Add(1)
+		go func(i int, c Charger) {
+			defer wg.Done()
+			s.Chargers[i] = read(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, r := range s.Chargers {
+		for i, a := range r.CurrentA {
+			s.CurrentA[i] += a
+		}
+	}
+	for i, a := range s.CurrentA {
+		s.HeadroomA[i] = s.BudgetA - a
+		if a > s.BudgetA {
+			s.OverBudget = true
+		}
+	}
+	return s
+}
+
+func read(ctx context.Context, c Charger) Reading {
+	r := Reading{Name: c.Name}
+	v, err := c.Vitals.Vitals(ctx)
+	if err != nil {
+		r.Err = fmt.Errorf("reading vitals of %s: %w", c.Name, err)
+		return r
+	}
+	r.CurrentA = []float64{v.GetCurrentAA(), v.GetCurrentBA(), v.GetCurrentCA()}
+	return r
+}
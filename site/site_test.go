@@ -0,0 +1,71 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/R167/wallconnector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixed struct {
+	v   *wallconnector.Vitals
+	err error
+}
+
+func (f fixed) Vitals(context.Context) (*wallconnector.Vitals, error) {
+	return f.v, f.err
+}
+
+func TestBudget(t *testing.T) {
+	b := NewBudget([]Charger{
+		{"garage", fixed{v: &wallconnector.Vitals{CurrentAA: 32, CurrentBA: 32}}},
+		{"driveway", fixed{v: &wallconnector.Vitals{CurrentAA: 40, CurrentBA: 40}}},
+	}, 100)
+
+	s := b.Read(context.Background())
+	assert.True(t, s.Complete())
+	assert.Equal(t, 80.0, s.BudgetA)
+	assert.Equal(t, []float64{72, 72, 0}, s.CurrentA)
+	assert.Equal(t, []float64{8, 8, 80}, s.HeadroomA)
+	assert.False(t, s.OverBudget)
+
+	b = NewBudget(b.chargers, 100, WithDerate(0.7))
+	s = b.Read(context.Background())
+	assert.Equal(t, []float64{-2, -2, 70}, s.HeadroomA)
+	assert.True(t, s.OverBudget)
+}
+
+func TestBudgetMetrics(t *testing.T) {
+	b := NewBudget([]Charger{
+		{"garage", fixed{v: &wallconnector.Vitals{CurrentAA: 16, CurrentBA: 16, CurrentCA: 16}}},
+		{"offline", fixed{err: errors.New("connection refused")}},
+	}, 18)
+
+	s := b.Read(context.Background())
+	assert.False(t, s.Complete())
+	assert.EqualError(t, s.Chargers[1].Err, "reading vitals of offline: connection refused")
+
+	assert.Equal(t, 1, testutil.CollectAndCount(b, "wallconnector_site_over_budget"))
+	problems, err := testutil.CollectAndLint(b)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(b)
+	mfs, err := reg.Gather()
+	assert.NoError(t, err)
+	values := make(map[string][]float64)
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			values[mf.GetName()] = append(values[mf.GetName()], m.GetGauge().GetValue())
+		}
+	}
+	// Sorted by label, so garage first.
+	assert.Equal(t, []float64{1, 0}, values["wallconnector_site_charger_up"])
+	assert.InDeltaSlice(t, []float64{-1.6, -1.6, -1.6}, values["wallconnector_site_headroom_amperes"], 1e-9)
+	assert.Equal(t, []float64{1}, values["wallconnector_site_over_budget"])
+}
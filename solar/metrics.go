@@ -0,0 +1,79 @@
+package solar
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	production  *prometheus.Desc
+	consumption *prometheus.Desc
+	surplus     *prometheus.Desc
+	recommended *prometheus.Desc
+	errors      prometheus.Counter
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		production: prometheus.NewDesc(
+			"wallconnector_solar_production_watts",
+			"Power produced by the solar array.",
+			nil, nil,
+		),
+		consumption: prometheus.NewDesc(
+			"wallconnector_solar_consumption_watts",
+			"Power consumed by the site.",
+			nil, nil,
+		),
+		surplus: prometheus.NewDesc(
+			"wallconnector_solar_surplus_watts",
+			"Solar power available to the charger, including what it draws.",
+			nil, nil,
+		),
+		recommended: prometheus.NewDesc(
+			"wallconnector_solar_recommended_amperes",
+			"Current to charge at to only use the solar surplus.",
+			nil, nil,
+		),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "wallconnector",
+			Subsystem: "solar",
+			Name:      "source_errors_total",
+			Help:      "Number of failed reads of the production and consumption of the site.",
+		}),
+	}
+}
+
+func (c *Calculator) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.metrics.production
+	ch <- c.metrics.consumption
+	ch <- c.metrics.surplus
+	ch <- c.metrics.recommended
+	c.metrics.errors.Describe(ch)
+}
+
+func (c *Calculator) Collect(ch chan<- prometheus.Metric) {
+	if s, ok := c.Latest(); ok {
+		ch <- prometheus.MustNewConstMetric(c.metrics.production, prometheus.GaugeValue, s.ProductionW)
+		ch <- prometheus.MustNewConstMetric(c.metrics.consumption, prometheus.GaugeValue, s.ConsumptionW)
+		ch <- prometheus.MustNewConstMetric(c.metrics.surplus, prometheus.GaugeValue, s.SurplusW)
+		ch <- prometheus.MustNewConstMetric(c.metrics.recommended, prometheus.GaugeValue, s.RecommendedA)
+	}
+	c.metrics.errors.Collect(ch)
+}
+
+// ServeHTTP serves the latest surplus as JSON.
+func (c *Calculator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s, ok := c.Latest()
+	if !ok {
+		http.Error(w, "no current surplus", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s)
+}
+
+// Ensure we implement the [prometheus.Collector] interface
+var _ prometheus.Collector = (*Calculator)(nil)
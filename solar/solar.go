@@ -0,0 +1,219 @@
+// Package solar computes the surplus of a rooftop solar array available to
+// charge a vehicle with, so it only charges on power which would otherwise be
+// exported to the grid.
+//
+// The [Calculator] combines readings of the production and consumption of the
+// site from a [Source] with the current and voltage of the charger, and
+// recommends the current to charge at.
+package solar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/R167/wallconnector"
+)
+
+type Config func(*calculatorOpts)
+
+type calculatorOpts struct {
+	// Range of current to charge at.
+	Min, Max float64
+
+	// Power to leave unused, in watts.
+	Reserve float64
+
+	// Whether the consumption excludes the charger.
+	ChargerExcluded bool
+
+	// Maximum age of the vitals to compute the surplus with.
+	MaxVitalsAge time.Duration
+}
+
+// WithCurrentRange sets the range of current to recommend. No current is
+// recommended when the surplus is less than min. Defaults to 6A, the minimum
+// J1772 allows, and 48A, the maximum of the wall connector.
+func WithCurrentRange(min, max float64) func(*calculatorOpts) {
+	return func(opts *calculatorOpts) {
+		opts.Min = min
+		opts.Max = max
+	}
+}
+
+// WithReserve leaves watts of the surplus unused, to absorb clouds and loads
+// which start between readings. Defaults to 0.
+func WithReserve(watts float64) func(*calculatorOpts) {
+	return func(opts *calculatorOpts) {
+		opts.Reserve = watts
+	}
+}
+
+// WithChargerExcluded is for sources whose consumption doesn't include the
+// charger, e.g. when it is on a circuit the energy monitor doesn't measure.
+func WithChargerExcluded() func(*calculatorOpts) {
+	return func(opts *calculatorOpts) {
+		opts.ChargerExcluded = true
+	}
+}
+
+// WithMaxVitalsAge sets how old the latest vitals may be to compute the
+// surplus with, so a charger which went offline doesn't keep getting
+// recommendations. Defaults to a minute.
+func WithMaxVitalsAge(d time.Duration) func(*calculatorOpts) {
+	return func(opts *calculatorOpts) {
+		opts.MaxVitalsAge = d
+	}
+}
+
+// A Surplus is the power available to charge with at a point in time.
+type Surplus struct {
+	Reading
+
+	GridV float64 `json:"grid_v"`
+	// Power drawn by the charger in watts.
+	ChargerW float64 `json:"charger_w"`
+	// Power available to the charger in watts, including what it already
+	// draws. Negative when the site imports from the grid even without the
+	// charger.
+	SurplusW float64 `json:"surplus_w"`
+	// Current to charge at in amps, zero when the surplus is too small to
+	// charge with.
+	RecommendedA float64 `json:"recommended_a"`
+}
+
+// A Calculator computes the solar surplus of a site with a single charger.
+// It is safe for concurrent use.
+type Calculator struct {
+	source Source
+	opts   *calculatorOpts
+
+	mu     sync.Mutex
+	vitals wallconnector.VitalsSample
+	latest *Surplus
+	hooks  []func(Surplus)
+
+	metrics *metrics
+	now     func() time.Time
+}
+
+func NewCalculator(source Source, opts ...Config) *Calculator {
+	o := &calculatorOpts{
+		Min:          6,
+		Max:          48,
+		MaxVitalsAge: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Calculator{
+		source:  source,
+		opts:    o,
+		metrics: newMetrics(),
+		now:     time.Now,
+	}
+}
+
+// OnSurplus registers a handler to call with every computed surplus.
+func (c *Calculator) OnSurplus(h func(Surplus)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, h)
+}
+
+// Vitals records the latest vitals of the charger. Samples with errors are
+// ignored.
+func (c *Calculator) Vitals(s wallconnector.VitalsSample) {
+	if s.Err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.vitals = s
+}
+
+// Latest returns the last computed surplus, and whether there is one. There
+// is none after the last update failed.
+func (c *Calculator) Latest() (Surplus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.latest == nil {
+		return Surplus{}, false
+	}
+	return *c.latest, true
+}
+
+// Update reads the source and computes the surplus with the latest vitals.
+// On failure, the previous surplus is cleared rather than left to look
+// current.
+func (c *Calculator) Update(ctx context.Context) (Surplus, error) {
+	r, err := c.source.Read(ctx)
+	if err != nil {
+		c.metrics.errors.Inc()
+		c.mu.Lock()
+		c.latest = nil
+		c.mu.Unlock()
+		return Surplus{}, err
+	}
+
+	c.mu.Lock()
+	switch {
+	case c.vitals.Vitals == nil || c.vitals.Vitals.GetGridV() <= 0:
+		err = errors.New("no grid voltage from the charger yet")
+	case c.now().Sub(c.vitals.Time) > c.opts.MaxVitalsAge:
+		err = fmt.Errorf("vitals of the charger are %v old", c.now().Sub(c.vitals.Time).Round(time.Second))
+	}
+	if err != nil {
+		c.latest = nil
+		c.mu.Unlock()
+		return Surplus{}, err
+	}
+	s := c.surplus(r, c.vitals.Vitals)
+	c.latest = &s
+	hooks := c.hooks
+	c.mu.Unlock()
+
+	for _, h := range hooks {
+		h(s)
+	}
+	return s, nil
+}
+
+func (c *Calculator) surplus(r Reading, v *wallconnector.Vitals) Surplus {
+	s := Surplus{
+		Reading:  r,
+		GridV:    v.GetGridV(),
+		ChargerW: v.GetVehicleCurrentA() * v.GetGridV(),
+	}
+	s.SurplusW = r.ProductionW - r.ConsumptionW - c.opts.Reserve
+	if !c.opts.ChargerExcluded {
+		// The charger would be free to use what it draws.
+		s.SurplusW += s.ChargerW
+	}
+	amps := math.Floor(math.Min(s.SurplusW/s.GridV, c.opts.Max))
+	if amps >= c.opts.Min {
+		s.RecommendedA = amps
+	}
+	return s
+}
+
+// Run updates the surplus every interval until ctx is done, logging errors.
+func (c *Calculator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.Update(ctx); err != nil {
+			log.Printf("solar surplus: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package solar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func vitals(gridV, current float64) wallconnector.VitalsSample {
+	return wallconnector.VitalsSample{Time: time.Now(), Vitals: &wallconnector.Vitals{GridV: gridV, VehicleCurrentA: current}}
+}
+
+func TestCalculator(t *testing.T) {
+	ctx := context.Background()
+	stub := NewStub(6000, 1200)
+	c := NewCalculator(stub)
+
+	_, err := c.Update(ctx)
+	assert.ErrorContains(t, err, "no grid voltage")
+
+	var published []Surplus
+	c.OnSurplus(func(s Surplus) { published = append(published, s) })
+
+	// Not charging, 4800W is 20A at 240V.
+	c.Vitals(vitals(240, 0))
+	s, err := c.Update(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4800.0, s.SurplusW)
+	assert.Equal(t, 20.0, s.RecommendedA)
+
+	// Consumption includes the 20A the vehicle now draws, which stays free.
+	stub.Set(6000, 6000)
+	c.Vitals(vitals(240, 20))
+	s, err = c.Update(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4800.0, s.ChargerW)
+	assert.Equal(t, 4800.0, s.SurplusW)
+	assert.Equal(t, 20.0, s.RecommendedA)
+
+	// A cloud leaves less than the minimum current.
+	stub.Set(2000, 6000)
+	s, err = c.Update(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 800.0, s.SurplusW)
+	assert.Equal(t, 0.0, s.RecommendedA)
+
+	assert.Len(t, published, 3)
+	latest, ok := c.Latest()
+	assert.True(t, ok)
+	assert.Equal(t, s, latest)
+
+	problems, err := testutil.CollectAndLint(c)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCalculatorOptions(t *testing.T) {
+	c := NewCalculator(NewStub(12000, 500), WithChargerExcluded(), WithReserve(500), WithCurrentRange(8, 32))
+	c.Vitals(vitals(240, 10))
+	s, err := c.Update(context.Background())
+	assert.NoError(t, err)
+	// The charger isn't in the consumption, so isn't added back.
+	assert.Equal(t, 11000.0, s.SurplusW)
+	assert.Equal(t, 32.0, s.RecommendedA)
+}
+
+func TestCalculatorStale(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "solar.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"production_w": 6000, "consumption_w": 1200}`), 0o644))
+	c := NewCalculator(NewFileSource(path))
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Vitals(vitals(240, 0))
+	_, err := c.Update(ctx)
+	assert.NoError(t, err)
+	_, ok := c.Latest()
+	assert.True(t, ok)
+
+	// The feed goes offline.
+	assert.NoError(t, os.Remove(path))
+	_, err = c.Update(ctx)
+	assert.Error(t, err)
+	_, ok = c.Latest()
+	assert.False(t, ok)
+	assert.Equal(t, 0, testutil.CollectAndCount(c, "wallconnector_solar_recommended_amperes"))
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest("GET", "/solar", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	// It recovers, but the charger stopped reporting.
+	assert.NoError(t, os.WriteFile(path, []byte(`{"production_w": 6000, "consumption_w": 1200}`), 0o644))
+	_, err = c.Update(ctx)
+	assert.NoError(t, err)
+	c.Vitals(wallconnector.VitalsSample{Time: now, Err: errors.New("timeout")})
+	now = now.Add(2 * time.Minute)
+	_, err = c.Update(ctx)
+	assert.EqualError(t, err, "vitals of the charger are 2m0s old")
+	_, ok = c.Latest()
+	assert.False(t, ok)
+}
+
+func TestHTTPSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"site": {"solar_w": 3500.5, "load_w": 900}, "battery_w": 0}`))
+	}))
+	defer srv.Close()
+
+	r, err := NewHTTPSource(srv.URL, WithFields("site.solar_w", "site.load_w")).Read(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3500.5, r.ProductionW)
+	assert.Equal(t, 900.0, r.ConsumptionW)
+
+	_, err = NewHTTPSource(srv.URL, WithFields("site.solar_w", "site.grid_w")).Read(context.Background())
+	assert.EqualError(t, err, `site.grid_w: missing "grid_w"`)
+	_, err = NewHTTPSource(srv.URL, WithFields("battery_w.soc", "site.load_w")).Read(context.Background())
+	assert.EqualError(t, err, `battery_w.soc: "soc" is not an object`)
+}
+
+func TestStubServesDefaultFormat(t *testing.T) {
+	srv := httptest.NewServer(NewStub(4000, 1000))
+	defer srv.Close()
+
+	r, err := NewHTTPSource(srv.URL).Read(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4000.0, r.ProductionW)
+	assert.Equal(t, 1000.0, r.ConsumptionW)
+}
+
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solar.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"production_w": 100, "consumption_w": "n/a"}`), 0o644))
+
+	_, err := NewFileSource(path).Read(context.Background())
+	assert.EqualError(t, err, "consumption_w: n/a is not a number")
+}
@@ -0,0 +1,182 @@
+package solar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Reading is the power produced and consumed by a site.
+type Reading struct {
+	Time time.Time `json:"time"`
+	// Power produced by the solar array in watts.
+	ProductionW float64 `json:"production_w"`
+	// Power consumed by the site in watts, including the charger unless the
+	// calculator is configured otherwise.
+	ConsumptionW float64 `json:"consumption_w"`
+}
+
+// A Source reads the production and consumption of a site, e.g. from the
+// local API of an inverter or energy monitor.
+type Source interface {
+	Read(ctx context.Context) (Reading, error)
+}
+
+type SourceConfig func(*sourceOpts)
+
+type sourceOpts struct {
+	// Dotted paths of the production and consumption in the JSON document.
+	Production  string
+	Consumption string
+
+	// Client to make HTTP requests with.
+	Client *http.Client
+}
+
+// WithFields sets the dotted paths to the production and consumption in
+// watts in the JSON document, e.g. "site.solar_w". Defaults to
+// "production_w" and "consumption_w".
+func WithFields(production, consumption string) func(*sourceOpts) {
+	return func(opts *sourceOpts) {
+		opts.Production = production
+		opts.Consumption = consumption
+	}
+}
+
+// WithHTTPClient sets the client to make requests to HTTP sources with.
+// Defaults to a client with a 10 second timeout.
+func WithHTTPClient(c *http.Client) func(*sourceOpts) {
+	return func(opts *sourceOpts) {
+		opts.Client = c
+	}
+}
+
+// jsonSource reads a JSON document and extracts the production and
+// consumption from it.
+type jsonSource struct {
+	opts *sourceOpts
+	read func(ctx context.Context) ([]byte, error)
+}
+
+func newJSONSource(opts []SourceConfig) *jsonSource {
+	o := &sourceOpts{
+		Production:  "production_w",
+		Consumption: "consumption_w",
+		Client:      &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &jsonSource{opts: o}
+}
+
+// NewHTTPSource reads the JSON document served at url.
+func NewHTTPSource(url string, opts ...SourceConfig) Source {
+	s := newJSONSource(opts)
+	s.read = func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.opts.Client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return s
+}
+
+// NewFileSource reads the JSON document in the file at path, e.g. one
+// written periodically by another program.
+func NewFileSource(path string, opts ...SourceConfig) Source {
+	s := newJSONSource(opts)
+	s.read = func(context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}
+	return s
+}
+
+func (s *jsonSource) Read(ctx context.Context) (Reading, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return Reading{}, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Reading{}, fmt.Errorf("decoding solar reading: %w", err)
+	}
+	r := Reading{Time: time.Now()}
+	if r.ProductionW, err = lookup(doc, s.opts.Production); err != nil {
+		return Reading{}, err
+	}
+	if r.ConsumptionW, err = lookup(doc, s.opts.Consumption); err != nil {
+		return Reading{}, err
+	}
+	return r, nil
+}
+
+// lookup returns the number at the dotted path in doc.
+func lookup(doc any, path string) (float64, error) {
+	v := doc
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return 0, fmt.Errorf("%s: %q is not an object", path, key)
+		}
+		if v, ok = obj[key]; !ok {
+			return 0, fmt.Errorf("%s: missing %q", path, key)
+		}
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s: %v is not a number", path, v)
+	}
+	return f, nil
+}
+
+// A Stub is a source with readings set by hand, to try out the calculator
+// without an inverter. It also serves its reading as JSON in the default
+// format, so it can stand in for an HTTP source. It is safe for concurrent
+// use.
+type Stub struct {
+	mu      sync.Mutex
+	reading Reading
+}
+
+func NewStub(productionW, consumptionW float64) *Stub {
+	s := &Stub{}
+	s.Set(productionW, consumptionW)
+	return s
+}
+
+// Set sets the production and consumption of the stub in watts.
+func (s *Stub) Set(productionW, consumptionW float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reading = Reading{ProductionW: productionW, ConsumptionW: consumptionW}
+}
+
+func (s *Stub) Read(ctx context.Context) (Reading, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.reading
+	r.Time = time.Now()
+	return r, nil
+}
+
+func (s *Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reading, _ := s.Read(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reading)
+}
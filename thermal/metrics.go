@@ -0,0 +1,87 @@
+package thermal
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	headroom    *prometheus.Desc
+	timeToLimit *prometheus.Desc
+	coefficient *prometheus.Desc
+	drops       *prometheus.CounterVec
+
+	// Heating coefficients of the last session with enough current.
+	coefficients map[string]float64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		headroom: prometheus.NewDesc(
+			"wallconnector_thermal_headroom_celsius",
+			"Difference between the limit and the temperature of a sensor.",
+			[]string{"sensor"}, nil,
+		),
+		timeToLimit: prometheus.NewDesc(
+			"wallconnector_thermal_time_to_limit_seconds",
+			"Predicted time until a sensor reaches its limit, from its recent trend while charging.",
+			[]string{"sensor"}, nil,
+		),
+		coefficient: prometheus.NewDesc(
+			"wallconnector_thermal_rise_celsius_per_square_ampere",
+			"Temperature rise of a sensor over the last session per square ampere of its peak current.",
+			[]string{"sensor"}, nil,
+		),
+		drops: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "wallconnector",
+			Subsystem: "thermal",
+			Name:      "foldback_drops_total",
+			Help:      "Number of drops in current while charging as the temperature rose, by the sensor which rose the most.",
+		}, []string{"sensor"}),
+	}
+}
+
+func (a *Analyzer) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.metrics.headroom
+	ch <- a.metrics.timeToLimit
+	ch <- a.metrics.coefficient
+	a.metrics.drops.Describe(ch)
+}
+
+func (a *Analyzer) Collect(ch chan<- prometheus.Metric) {
+	for sensor, h := range a.Headroom() {
+		ch <- prometheus.MustNewConstMetric(a.metrics.headroom, prometheus.GaugeValue, h, sensor)
+	}
+	for sensor, d := range a.TimeToLimit() {
+		ch <- prometheus.MustNewConstMetric(a.metrics.timeToLimit, prometheus.GaugeValue, d.Seconds(), sensor)
+	}
+
+	a.mu.Lock()
+	coefficients := a.metrics.coefficients
+	a.mu.Unlock()
+	// Replaced rather than modified, so safe to read without the lock.
+	for sensor, c := range coefficients {
+		ch <- prometheus.MustNewConstMetric(a.metrics.coefficient, prometheus.GaugeValue, c, sensor)
+	}
+	a.metrics.drops.Collect(ch)
+}
+
+// ServeHTTP serves the sessions as JSON, as evidence of how the charger heats
+// up over time.
+func (a *Analyzer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	type session struct {
+		Session
+		Coefficients map[string]float64 `json:"rise_celsius_per_square_ampere,omitempty"`
+	}
+	var sessions []session
+	for _, s := range a.Sessions() {
+		sessions = append(sessions, session{Session: s, Coefficients: s.Coefficients()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"sessions": sessions})
+}
+
+// Ensure we implement the [prometheus.Collector] interface
+var _ prometheus.Collector = (*Analyzer)(nil)
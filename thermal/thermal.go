@@ -0,0 +1,432 @@
+// Package thermal analyzes the temperatures of a wall connector against the
+// current it delivers, to find chargers or circuits which run hot.
+//
+// The [Analyzer] tracks charging sessions, detects thermal foldbacks (the
+// charger cutting current as it heats up) from vitals and confirms them with
+// the lifetime foldback counter, and predicts how long until each sensor
+// reaches its limit.
+package thermal
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/R167/wallconnector/events"
+)
+
+// A Sensor is a temperature sensor of the wall connector.
+type Sensor struct {
+	Name string
+	Temp func(*wallconnector.Vitals) float64
+
+	// Temperatures in celsius to warn at, and at which the charger is
+	// expected to fold back.
+	Warn, Limit float64
+}
+
+// Sensors of the wall connector, with their default temperatures.
+//
+// Tesla doesn't publish the temperatures at which the wall connector folds
+// back, so these are conservative estimates rather than specifications.
+// Override them with [WithSensors] if a charger folds back at other
+// temperatures.
+var Sensors = []Sensor{
+	{"handle", (*wallconnector.Vitals).GetHandleTempC, 50, 60},
+	{"pcba", (*wallconnector.Vitals).GetPcbaTempC, 70, 85},
+	{"mcu", (*wallconnector.Vitals).GetMcuTempC, 75, 90},
+}
+
+const (
+	// Window of samples to find the recent peak current and temperature
+	// trend in.
+	window = 5 * time.Minute
+
+	// Minimum drop in current from the recent peak to be a foldback, as a
+	// fraction of the peak.
+	foldbackDrop = 0.2
+
+	// Minimum rise in temperature over the window for a drop in current to
+	// be a foldback.
+	foldbackRise = 1.0
+
+	// How long after a detected foldback the lifetime counter may confirm it.
+	confirmWindow = 10 * time.Minute
+
+	// Minimum peak current for a session to have a meaningful heating
+	// coefficient.
+	minCoefficientCurrent = 10.0
+
+	// Number of sessions to keep.
+	maxSessions = 50
+)
+
+type Config func(*analyzerOpts)
+
+type analyzerOpts struct {
+	// Sensors to analyze, with their limits.
+	Sensors []Sensor
+}
+
+// WithSensors sets the sensors to analyze. Defaults to [Sensors].
+func WithSensors(sensors []Sensor) func(*analyzerOpts) {
+	return func(opts *analyzerOpts) {
+		opts.Sensors = sensors
+	}
+}
+
+// ParseLimits returns [Sensors] with the limits overridden by s, a comma
+// separated list of sensor=celsius, e.g. "handle=65,pcba=90". The warning
+// temperatures keep their margin below the limits.
+func ParseLimits(s string) ([]Sensor, error) {
+	sensors := slices.Clone(Sensors)
+	for _, kv := range strings.Split(s, ",") {
+		name, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid limit %q, expected sensor=celsius", kv)
+		}
+		limit, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit of %s: %w", name, err)
+		}
+		i := slices.IndexFunc(sensors, func(s Sensor) bool { return s.Name == strings.TrimSpace(name) })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown sensor %q", name)
+		}
+		sensors[i].Warn += limit - sensors[i].Limit
+		sensors[i].Limit = limit
+	}
+	return sensors, nil
+}
+
+// A Foldback is a drop in current while charging caused by the temperature.
+type Foldback struct {
+	Time time.Time `json:"time"`
+
+	// Vehicle current before and after the drop.
+	From float64 `json:"from_amperes"`
+	To   float64 `json:"to_amperes"`
+
+	// Temperatures at the drop, by sensor.
+	Temps map[string]float64 `json:"temps_celsius"`
+
+	// Whether the lifetime foldback counter of the charger incremented. A
+	// foldback found only by the counter has no From or To.
+	Confirmed bool `json:"confirmed"`
+}
+
+// A Session is the time a vehicle was connected.
+type Session struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitempty"`
+
+	PeakCurrent float64 `json:"peak_current_amperes"`
+
+	// Temperatures at the start of the session and their peaks, by sensor.
+	StartTemps map[string]float64 `json:"start_temps_celsius"`
+	PeakTemps  map[string]float64 `json:"peak_temps_celsius"`
+
+	Foldbacks []Foldback `json:"foldbacks,omitempty"`
+}
+
+// Coefficients returns the temperature rise of each sensor over the session
+// per square ampere of peak current. Resistive heating is proportional to the
+// square of the current, so a circuit with a poor connection shows a higher
+// coefficient than its past sessions or other chargers. Returns nil if the
+// peak current is too low to be meaningful.
+func (s *Session) Coefficients() map[string]float64 {
+	if s.PeakCurrent < minCoefficientCurrent {
+		return nil
+	}
+	c := make(map[string]float64, len(s.PeakTemps))
+	for name, peak := range s.PeakTemps {
+		c[name] = (peak - s.StartTemps[name]) / (s.PeakCurrent * s.PeakCurrent)
+	}
+	return c
+}
+
+// clone returns a copy of s which shares nothing the analyzer modifies.
+// Foldbacks are copied, but their temperatures are never modified so are
+// shared.
+func (s *Session) clone() Session {
+	c := *s
+	c.StartTemps = maps.Clone(s.StartTemps)
+	c.PeakTemps = maps.Clone(s.PeakTemps)
+	c.Foldbacks = slices.Clone(s.Foldbacks)
+	return c
+}
+
+// An Analyzer analyzes successive polls of a single charger. It is safe for
+// concurrent use.
+type Analyzer struct {
+	opts *analyzerOpts
+
+	mu sync.Mutex
+
+	// Recent samples while charging, oldest first.
+	recent []wallconnector.VitalsSample
+	last   wallconnector.VitalsSample
+	// Whether the current has dropped since the recent peak.
+	folded bool
+
+	session  *Session
+	sessions []Session
+
+	lifetime  bool
+	foldbacks int32
+
+	metrics *metrics
+}
+
+func NewAnalyzer(opts ...Config) *Analyzer {
+	o := &analyzerOpts{Sensors: Sensors}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Analyzer{opts: o, metrics: newMetrics()}
+}
+
+// Vitals adds a vitals sample. Samples with errors are ignored.
+func (a *Analyzer) Vitals(s wallconnector.VitalsSample) {
+	if s.Err != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	v := s.Vitals
+	a.last = s
+
+	a.trackSession(s)
+
+	charging := v.GetContactorClosed() && v.GetVehicleCurrentA() > events.DefaultChargeThreshold
+	if !charging {
+		a.recent = a.recent[:0]
+		a.folded = false
+		return
+	}
+	a.recent = append(a.recent, s)
+	for len(a.recent) > 0 && s.Time.Sub(a.recent[0].Time) > window {
+		a.recent = a.recent[1:]
+	}
+	a.detectFoldback(s)
+}
+
+func (a *Analyzer) trackSession(s wallconnector.VitalsSample) {
+	v := s.Vitals
+	if !v.GetVehicleConnected() {
+		if a.session != nil {
+			a.session.End = s.Time
+			a.endSession()
+		}
+		return
+	}
+
+	if a.session == nil {
+		a.session = &Session{
+			Start:      s.Time,
+			StartTemps: a.temps(v),
+			PeakTemps:  a.temps(v),
+		}
+	}
+	a.session.PeakCurrent = max(a.session.PeakCurrent, v.GetVehicleCurrentA())
+	for _, sensor := range a.opts.Sensors {
+		a.session.PeakTemps[sensor.Name] = max(a.session.PeakTemps[sensor.Name], sensor.Temp(v))
+	}
+}
+
+func (a *Analyzer) endSession() {
+	a.sessions = append(a.sessions, *a.session)
+	if len(a.sessions) > maxSessions {
+		a.sessions = a.sessions[1:]
+	}
+	if c := a.session.Coefficients(); c != nil {
+		a.metrics.coefficients = c
+	}
+	a.session = nil
+}
+
+// detectFoldback checks whether the latest sample, while charging, dropped
+// current from the recent peak as the temperature rose.
+func (a *Analyzer) detectFoldback(s wallconnector.VitalsSample) {
+	current := s.Vitals.GetVehicleCurrentA()
+	var peak float64
+	for _, r := range a.recent {
+		peak = max(peak, r.Vitals.GetVehicleCurrentA())
+	}
+	if current >= peak*(1-foldbackDrop/2) {
+		// Recovered, or never dropped.
+		a.folded = false
+		return
+	}
+	if a.folded || current > peak*(1-foldbackDrop) {
+		return
+	}
+
+	first := a.recent[0].Vitals
+	var hottest string
+	var rise float64
+	for _, sensor := range a.opts.Sensors {
+		if r := sensor.Temp(s.Vitals) - sensor.Temp(first); r > rise {
+			hottest, rise = sensor.Name, r
+		}
+	}
+	if rise < foldbackRise {
+		return
+	}
+
+	a.folded = true
+	a.addFoldback(Foldback{
+		Time:  s.Time,
+		From:  peak,
+		To:    current,
+		Temps: a.temps(s.Vitals),
+	})
+	a.metrics.drops.WithLabelValues(hottest).Inc()
+}
+
+func (a *Analyzer) addFoldback(f Foldback) {
+	if a.session != nil {
+		a.session.Foldbacks = append(a.session.Foldbacks, f)
+		return
+	}
+	// Foldbacks are only reported while charging, so this is a counter
+	// increment seen after the session ended.
+	if n := len(a.sessions); n > 0 {
+		a.sessions[n-1].Foldbacks = append(a.sessions[n-1].Foldbacks, f)
+	}
+}
+
+// Lifetime adds a poll of the lifetime stats, polled at t, confirming recent
+// foldbacks when the foldback counter increments.
+func (a *Analyzer) Lifetime(t time.Time, l *wallconnector.Lifetime) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	count := l.GetThermalFoldbackCount()
+	prev, seen := a.foldbacks, a.lifetime
+	a.foldbacks, a.lifetime = count, true
+	if !seen || count <= prev {
+		return
+	}
+
+	for n := count - prev; n > 0; n-- {
+		if f := a.unconfirmed(t); f != nil {
+			f.Confirmed = true
+			continue
+		}
+		f := Foldback{Time: t, Confirmed: true}
+		if a.last.Vitals != nil {
+			f.Temps = a.temps(a.last.Vitals)
+		}
+		a.addFoldback(f)
+	}
+}
+
+// unconfirmed returns the most recent unconfirmed foldback detected within
+// the confirm window of t.
+func (a *Analyzer) unconfirmed(t time.Time) *Foldback {
+	sessions := make([]*Session, 0, 2)
+	if a.session != nil {
+		sessions = append(sessions, a.session)
+	}
+	if n := len(a.sessions); n > 0 {
+		sessions = append(sessions, &a.sessions[n-1])
+	}
+	for _, s := range sessions {
+		for i := len(s.Foldbacks) - 1; i >= 0; i-- {
+			f := &s.Foldbacks[i]
+			if t.Sub(f.Time) > confirmWindow {
+				return nil
+			}
+			if !f.Confirmed {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// Sessions returns the completed sessions, oldest first, followed by the
+// current session, if any.
+func (a *Analyzer) Sessions() []Session {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	sessions := make([]Session, 0, len(a.sessions)+1)
+	for i := range a.sessions {
+		sessions = append(sessions, a.sessions[i].clone())
+	}
+	if a.session != nil {
+		sessions = append(sessions, a.session.clone())
+	}
+	return sessions
+}
+
+// Headroom returns the difference between the limit and the latest
+// temperature of each sensor.
+func (a *Analyzer) Headroom() map[string]float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.last.Vitals == nil {
+		return nil
+	}
+	h := make(map[string]float64, len(a.opts.Sensors))
+	for _, sensor := range a.opts.Sensors {
+		h[sensor.Name] = sensor.Limit - sensor.Temp(a.last.Vitals)
+	}
+	return h
+}
+
+// TimeToLimit predicts how long until each sensor reaches its limit, by
+// extrapolating the trend of its temperature while charging. Sensors which
+// aren't heating up are omitted.
+func (a *Analyzer) TimeToLimit() map[string]time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.recent) < 2 {
+		return nil
+	}
+	last := a.recent[len(a.recent)-1].Vitals
+	predictions := make(map[string]time.Duration)
+	for _, sensor := range a.opts.Sensors {
+		slope := trend(a.recent, sensor.Temp)
+		if slope <= 0 {
+			continue
+		}
+		seconds := max(sensor.Limit-sensor.Temp(last), 0) / slope
+		predictions[sensor.Name] = time.Duration(seconds * float64(time.Second))
+	}
+	return predictions
+}
+
+// trend returns the least squares slope of temp over time, per second.
+func trend(samples []wallconnector.VitalsSample, temp func(*wallconnector.Vitals) float64) float64 {
+	start := samples[0].Time
+	var n, sx, sy, sxx, sxy float64
+	for _, s := range samples {
+		x := s.Time.Sub(start).Seconds()
+		y := temp(s.Vitals)
+		n++
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+	d := n*sxx - sx*sx
+	if d == 0 || math.IsNaN(d) {
+		return 0
+	}
+	return (n*sxy - sx*sy) / d
+}
+
+func (a *Analyzer) temps(v *wallconnector.Vitals) map[string]float64 {
+	t := make(map[string]float64, len(a.opts.Sensors))
+	for _, sensor := range a.opts.Sensors {
+		t[sensor.Name] = sensor.Temp(v)
+	}
+	return t
+}
@@ -0,0 +1,145 @@
+package thermal
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/R167/wallconnector"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func sample(t time.Time, connected bool, current, handle float64) wallconnector.VitalsSample {
+	return wallconnector.VitalsSample{Time: t, Vitals: &wallconnector.Vitals{
+		VehicleConnected: connected,
+		ContactorClosed:  current > 0,
+		VehicleCurrentA:  current,
+		HandleTempC:      handle,
+		PcbaTempC:        40,
+		McuTempC:         45,
+	}}
+}
+
+func TestAnalyzer(t *testing.T) {
+	a := NewAnalyzer()
+	start := time.Unix(0, 0).UTC()
+	a.Lifetime(start, &wallconnector.Lifetime{ThermalFoldbackCount: 3})
+
+	// Charge at 32A while the handle heats up by 0.5°C a minute.
+	now := start
+	handle := 30.0
+	for i := 0; i < 20; i++ {
+		a.Vitals(sample(now, true, 32, handle))
+		now = now.Add(30 * time.Second)
+		handle += 0.25
+	}
+	assert.InDelta(t, 60-handle+0.25, a.Headroom()["handle"], 0.001)
+	assert.InDelta(t, (60-handle+0.25)/0.5, a.TimeToLimit()["handle"].Minutes(), 0.01)
+	assert.NotContains(t, a.TimeToLimit(), "pcba")
+
+	// The charger folds back to 24A.
+	a.Vitals(sample(now, true, 24, handle))
+	now = now.Add(30 * time.Second)
+	a.Vitals(sample(now, true, 24, handle))
+	assert.Equal(t, 1.0, testutil.ToFloat64(a.metrics.drops.WithLabelValues("handle")))
+
+	a.Lifetime(now, &wallconnector.Lifetime{ThermalFoldbackCount: 4})
+
+	// The charge finishes, which isn't a foldback.
+	now = now.Add(30 * time.Second)
+	a.Vitals(sample(now, true, 0, handle))
+	now = now.Add(30 * time.Second)
+	a.Vitals(sample(now, false, 0, handle))
+
+	sessions := a.Sessions()
+	if assert.Len(t, sessions, 1) {
+		s := sessions[0]
+		assert.Equal(t, start, s.Start)
+		assert.Equal(t, now, s.End)
+		assert.Equal(t, 32.0, s.PeakCurrent)
+		if assert.Len(t, s.Foldbacks, 1) {
+			f := s.Foldbacks[0]
+			assert.Equal(t, 32.0, f.From)
+			assert.Equal(t, 24.0, f.To)
+			assert.True(t, f.Confirmed)
+		}
+		assert.InDelta(t, (handle-30)/(32*32), s.Coefficients()["handle"], 0.0001)
+		assert.Equal(t, 0.0, s.Coefficients()["pcba"])
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(a.metrics.drops.WithLabelValues("handle")))
+}
+
+func TestAnalyzerCounterOnly(t *testing.T) {
+	a := NewAnalyzer()
+	start := time.Unix(0, 0).UTC()
+	a.Vitals(sample(start, true, 32, 30))
+	a.Lifetime(start, &wallconnector.Lifetime{ThermalFoldbackCount: 1})
+	a.Lifetime(start.Add(time.Minute), &wallconnector.Lifetime{ThermalFoldbackCount: 2})
+
+	sessions := a.Sessions()
+	if assert.Len(t, sessions, 1) && assert.Len(t, sessions[0].Foldbacks, 1) {
+		f := sessions[0].Foldbacks[0]
+		assert.True(t, f.Confirmed)
+		assert.Equal(t, 30.0, f.Temps["handle"])
+	}
+}
+
+func TestAnalyzerMetrics(t *testing.T) {
+	a := NewAnalyzer()
+	a.Vitals(sample(time.Unix(0, 0), true, 32, 30))
+	assert.Equal(t, 3, testutil.CollectAndCount(a, "wallconnector_thermal_headroom_celsius"))
+	problems, err := testutil.CollectAndLint(a)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestParseLimits(t *testing.T) {
+	sensors, err := ParseLimits("handle=65, pcba = 80")
+	assert.NoError(t, err)
+	assert.Equal(t, 55.0, sensors[0].Warn)
+	assert.Equal(t, 65.0, sensors[0].Limit)
+	assert.Equal(t, 80.0, sensors[1].Limit)
+	assert.Equal(t, 90.0, sensors[2].Limit)
+	// The defaults are unchanged.
+	assert.Equal(t, 60.0, Sensors[0].Limit)
+
+	_, err = ParseLimits("handle")
+	assert.EqualError(t, err, `invalid limit "handle", expected sensor=celsius`)
+	_, err = ParseLimits("cpu=80")
+	assert.EqualError(t, err, `unknown sensor "cpu"`)
+
+	a := NewAnalyzer(WithSensors(sensors))
+	a.Vitals(sample(time.Unix(0, 0), true, 32, 30))
+	assert.Equal(t, 35.0, a.Headroom()["handle"])
+}
+
+func TestAnalyzerServeWhilePolling(t *testing.T) {
+	a := NewAnalyzer()
+	start := time.Unix(0, 0).UTC()
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			now := start.Add(time.Duration(i) * 30 * time.Second)
+			// Sessions of 40 samples, so there are never more than
+			// maxSessions to serve.
+			a.Vitals(sample(now, i%40 != 0, 32-float64(i%20), 30+float64(i%40)/10))
+			a.Lifetime(now, &wallconnector.Lifetime{ThermalFoldbackCount: int32(i / 50)})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		w := httptest.NewRecorder()
+		a.ServeHTTP(w, httptest.NewRequest("GET", "/thermal", nil))
+		assert.Equal(t, 200, w.Code)
+		testutil.CollectAndCount(a)
+	}
+	close(stop)
+	<-done
+}
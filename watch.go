@@ -0,0 +1,76 @@
+package wallconnector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// A VitalsSample is a vitals poll of the wallconnector, or the error polling
+// it, along with the time of the poll.
+type VitalsSample struct {
+	Time   time.Time `json:"time"`
+	Vitals *Vitals   `json:"vitals,omitempty"`
+	Err    error     `json:"-"`
+}
+
+// Watch polls the vitals of the wallconnector every interval until ctx is
+// done, sending each sample on the returned channel. Samples identical to the
+// previous one are skipped, and errors are sent as samples with Err set. The
+// channel is closed once ctx is done.
+func (c *Client) Watch(ctx context.Context, interval time.Duration) <-chan VitalsSample {
+	ch := make(chan VitalsSample)
+	go func() {
+		defer close(ch)
+		c.WatchSeq(ctx, interval)(func(s VitalsSample) bool {
+			select {
+			case ch <- s:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return ch
+}
+
+// WatchSeq is like [Client.Watch], but returns an iterator over the samples
+// which polls while it is being ranged over. An interval which isn't positive
+// yields a single sample with the error.
+func (c *Client) WatchSeq(ctx context.Context, interval time.Duration) func(yield func(VitalsSample) bool) {
+	return func(yield func(VitalsSample) bool) {
+		if interval <= 0 {
+			yield(VitalsSample{Time: time.Now(), Err: fmt.Errorf("watch: interval must be positive, got %v", interval)})
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var prev *Vitals
+		for {
+			now := time.Now()
+			vitals, err := c.Vitals(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				prev = nil
+				if !yield(VitalsSample{Time: now, Err: err}) {
+					return
+				}
+			case prev == nil || !proto.Equal(prev, vitals):
+				prev = vitals
+				if !yield(VitalsSample{Time: now, Vitals: vitals}) {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package wallconnector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	responses := []string{`{"grid_v": 240}`, `{"grid_v": 240}`, `not json`, `{"grid_v": 240}`, `{"grid_v": 241}`}
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[min(polls, len(responses)-1)]))
+		polls++
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient(strings.TrimPrefix(srv.URL, "http://"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []VitalsSample
+	for s := range client.Watch(ctx, time.Millisecond) {
+		assert.False(t, s.Time.IsZero())
+		got = append(got, s)
+		if len(got) == 4 {
+			cancel()
+		}
+	}
+
+	if assert.Len(t, got, 4) {
+		// The duplicate second poll is skipped, but not after an error.
+		assert.Equal(t, 240.0, got[0].Vitals.GetGridV())
+		assert.Error(t, got[1].Err)
+		assert.Equal(t, 240.0, got[2].Vitals.GetGridV())
+		assert.Equal(t, 241.0, got[3].Vitals.GetGridV())
+	}
+}
+
+func TestWatchInvalidInterval(t *testing.T) {
+	client, _ := NewClient("127.0.0.1:1")
+	var got []VitalsSample
+	for s := range client.Watch(context.Background(), 0) {
+		got = append(got, s)
+	}
+	if assert.Len(t, got, 1) {
+		assert.EqualError(t, got[0].Err, "watch: interval must be positive, got 0s")
+	}
+}
+
+func TestWatchSeqStop(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Write([]byte(`{"uptime_s": ` + string(rune('0'+polls)) + `}`))
+	}))
+	defer srv.Close()
+
+	client, _ := NewClient(strings.TrimPrefix(srv.URL, "http://"))
+	n := 0
+	client.WatchSeq(context.Background(), time.Millisecond)(func(s VitalsSample) bool {
+		n++
+		return n < 3
+	})
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 3, polls)
+}